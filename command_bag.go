@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/capture"
+	"github.com/samersawan/pokedexcli/internal/inventory"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func commandBag(cfg *config) error {
+	fmt.Printf("Money: $%d\n", cfg.inventory.Money)
+	names := cfg.inventory.Names()
+	if len(names) == 0 {
+		fmt.Println("Your bag is empty.")
+		return nil
+	}
+	fmt.Println("Your Bag:")
+	for _, name := range names {
+		fmt.Printf(" - %s x%d\n", name, cfg.inventory.Count(name))
+	}
+	return nil
+}
+
+func commandBuy(cfg *config) error {
+	if len(cfg.args) < 1 || len(cfg.args) > 2 {
+		fmt.Println("You must specify an item to buy!")
+		return errors.New("missing argument")
+	}
+	qty := 1
+	if len(cfg.args) == 2 {
+		n, err := strconv.Atoi(cfg.args[1])
+		if err != nil || n < 1 {
+			fmt.Println("The quantity must be a positive number.")
+			return errors.New("invalid quantity")
+		}
+		qty = n
+	}
+
+	item, err := cfg.client.GetItem("https://pokeapi.co/api/v2/item/"+cfg.args[0], cfg.cache)
+	if err != nil {
+		fmt.Printf("Could not find item %s.\n", cfg.args[0])
+		return err
+	}
+	if item.Cost <= 0 {
+		fmt.Printf("%s is not for sale.\n", item.Name)
+		return errors.New("item not for sale")
+	}
+
+	err = cfg.inventory.Buy(item.Name, qty, item.Cost)
+	if errors.Is(err, inventory.ErrNotEnoughMoney) {
+		fmt.Printf("You only have $%d, which buys at most %d %s.\n", cfg.inventory.Money, cfg.inventory.Money/item.Cost, item.Name)
+		return err
+	}
+	fmt.Printf("Bought %d %s for $%d.\n", qty, item.Name, qty*item.Cost)
+	return nil
+}
+
+func commandUse(cfg *config) error {
+	if len(cfg.args) < 1 || len(cfg.args) > 2 {
+		fmt.Println("You must specify an item to use!")
+		return errors.New("missing argument")
+	}
+	name := cfg.args[0]
+	if cfg.inventory.Count(name) == 0 {
+		fmt.Printf("You don't have any %s.\n", name)
+		return inventory.ErrNotEnoughItems
+	}
+	if _, isBall := capture.Balls[name]; isBall {
+		cfg.args = []string{"--ball", name}
+		return commandCatch(cfg)
+	}
+	if len(cfg.args) == 2 {
+		if remedy, isMedicine := inventory.Medicine[name]; isMedicine {
+			c, err := caughtByID(cfg, cfg.args[1])
+			if err != nil {
+				return err
+			}
+			return useMedicine(cfg, name, remedy, c)
+		}
+		if cfg.battle != nil {
+			fmt.Printf("You can't use %s in the middle of a battle!\n", name)
+			return errBattleInProgress
+		}
+		c, err := caughtByID(cfg, cfg.args[1])
+		if err != nil {
+			return err
+		}
+		return useEvolutionItem(cfg, name, c)
+	}
+	fmt.Printf("You can't use %s right now.\n", name)
+	return errors.New("item can not be used")
+}
+
+// useMedicine heals one of the player's pokemon. In a battle it heals the
+// battling pokemon and uses up the player's turn.
+func useMedicine(cfg *config, name string, remedy inventory.Remedy, c *pokedex.Caught) error {
+	maxHP := c.Stats(cfg.pokedex.Species[c.Species])["hp"]
+	var combatant *battle.Combatant
+	if cfg.battle != nil {
+		for _, member := range cfg.battle.Sides[battle.Player].Team {
+			if member.ID == c.ID {
+				combatant = member
+			}
+		}
+	}
+
+	hp, status := maxHP-c.Damage, c.Status
+	if combatant != nil {
+		hp, status = combatant.HP, combatant.Status
+	}
+	newHP, newStatus, effect := remedy.Apply(hp, maxHP, status)
+	if !effect {
+		fmt.Println("It won't have any effect.")
+		return nil
+	}
+	if err := cfg.inventory.Remove(name, 1); err != nil {
+		return err
+	}
+
+	c.Damage, c.Status = maxHP-newHP, newStatus
+	if combatant != nil {
+		combatant.HP, combatant.Status = newHP, newStatus
+	}
+	if newHP > hp {
+		fmt.Printf("%s recovered %d HP.\n", c.Name(), newHP-hp)
+	}
+	if status != "" && newStatus == "" && hp > 0 {
+		fmt.Printf("%s is no longer affected by %s.\n", c.Name(), status)
+	}
+	if cfg.battle != nil {
+		playTurn(cfg, battle.Action{Kind: battle.Pass})
+	}
+	return nil
+}
+
+func commandHeal(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You can't go to a Pokemon Center in the middle of a battle!")
+		return errBattleInProgress
+	}
+	area, _ := cfg.world.Area(cfg.location)
+	if !area.PokemonCenter {
+		fmt.Println("There's no Pokemon Center here. Travel to a town to heal your pokemon.")
+		return errors.New("no pokemon center")
+	}
+	for _, c := range cfg.pokedex.PartyMembers() {
+		c.Heal()
+	}
+	fmt.Println("Your pokemon are fighting fit! We hope to see you again!")
+	return nil
+}
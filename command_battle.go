@@ -0,0 +1,302 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+const defaultVersionGroup = "red-blue"
+
+var (
+	errBattleInProgress = errors.New("battle in progress")
+	errNoBattle         = errors.New("no battle in progress")
+)
+
+// startWildBattle sends out the first party pokemon that can fight against
+// the wild encounter. Without one, the player can still throw Poke Balls.
+func startWildBattle(cfg *config) error {
+	team, err := partyCombatants(cfg)
+	if err != nil {
+		return err
+	}
+	if len(team) == 0 || battle.NewSide("You", team).Defeated() {
+		fmt.Println("You have no pokemon that can fight, but you can still try to catch it!")
+		return nil
+	}
+
+	cfg.battle = battle.New(
+		battle.NewSide("You", team),
+		battle.NewSide("The wild pokemon", []*battle.Combatant{cfg.wild.combatant}),
+		cfg.rng,
+	)
+	fmt.Printf("Go! %s!\n", cfg.battle.Sides[battle.Player].Current().Name)
+	printBattleStatus(cfg)
+	return nil
+}
+
+func commandFight(cfg *config) error {
+	if cfg.battle == nil {
+		fmt.Println("You're not in a battle!")
+		return errNoBattle
+	}
+	active := cfg.battle.Sides[battle.Player].Current()
+	if len(cfg.args) == 0 {
+		fmt.Printf("%s's moves:\n", active.Name)
+		for _, slot := range active.Moves {
+			fmt.Printf(" - %s (%s, power %d, PP %d/%d)\n", slot.Move.Name, slot.Move.Type, slot.Move.Power, slot.PP, slot.Move.PP)
+		}
+		return nil
+	}
+
+	index := active.MoveIndex(cfg.args[0])
+	if index < 0 {
+		fmt.Printf("%s doesn't know %s.\n", active.Name, cfg.args[0])
+		return errors.New("unknown move")
+	}
+	if active.Moves[index].PP == 0 && active.HasPP() {
+		fmt.Printf("There's no PP left for %s!\n", cfg.args[0])
+		return errors.New("no pp left")
+	}
+	playTurn(cfg, battle.Action{Kind: battle.Fight, Move: index})
+	return nil
+}
+
+func commandSwitch(cfg *config) error {
+	if cfg.battle == nil {
+		fmt.Println("You're not in a battle!")
+		return errNoBattle
+	}
+	if len(cfg.args) != 1 {
+		fmt.Println("You must specify the ID of the pokemon to switch to!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, cfg.args[0])
+	if err != nil {
+		return err
+	}
+
+	side := cfg.battle.Sides[battle.Player]
+	for i, member := range side.Team {
+		if member.ID != c.ID {
+			continue
+		}
+		if i == side.Active {
+			fmt.Printf("%s is already battling!\n", member.Name)
+			return errors.New("already active")
+		}
+		if member.Fainted() {
+			fmt.Printf("%s has no energy left to battle!\n", member.Name)
+			return errors.New("pokemon fainted")
+		}
+		playTurn(cfg, battle.Action{Kind: battle.Switch, Switch: i})
+		return nil
+	}
+	fmt.Printf("%s is not in your party.\n", c.Name())
+	return pokedex.ErrNotInParty
+}
+
+func commandRun(cfg *config) error {
+	if cfg.battle == nil {
+		if cfg.wild != nil {
+			fmt.Println("Got away safely!")
+			cfg.wild = nil
+			return nil
+		}
+		fmt.Println("There's nothing to run from!")
+		return errNoBattle
+	}
+	if cfg.opponent != nil {
+		fmt.Println("No! There's no running from a trainer battle!")
+		return errors.New("trainer battle")
+	}
+	if cfg.battle.TryEscape() {
+		fmt.Println("Got away safely!")
+		endBattle(cfg)
+		return nil
+	}
+	fmt.Println("Can't escape!")
+	playTurn(cfg, battle.Action{Kind: battle.Pass})
+	return nil
+}
+
+// playTurn plays the player's action against the opponent's, prints what
+// happened and wraps the battle up once one side has no pokemon left.
+func playTurn(cfg *config, action battle.Action) {
+	opponents := cfg.battle.Sides[battle.Opponent]
+	standing := []*battle.Combatant{}
+	for _, c := range opponents.Team {
+		if !c.Fainted() {
+			standing = append(standing, c)
+		}
+	}
+
+	var strategy battle.Strategy = battle.Random{}
+	if cfg.opponent != nil {
+		strategy = cfg.opponent.Strategy()
+	}
+	for _, line := range cfg.battle.Step([2]battle.Action{action, strategy.Choose(cfg.battle, battle.Opponent)}) {
+		fmt.Println(line)
+	}
+
+	knockedOut := []*battle.Combatant{}
+	for _, c := range standing {
+		if c.Fainted() {
+			knockedOut = append(knockedOut, c)
+		}
+	}
+	if err := rewardKnockouts(cfg, knockedOut, cfg.opponent != nil); err != nil {
+		fmt.Println("Could not award experience:", err)
+	}
+
+	switch cfg.battle.Winner() {
+	case battle.Player:
+		fmt.Println("You won the battle!")
+		if cfg.opponent != nil {
+			trainerDefeated(cfg, cfg.opponent)
+		}
+		endBattle(cfg)
+		evolveLeveledUp(cfg)
+	case battle.Opponent:
+		fmt.Println("You have no more pokemon that can fight. You blacked out!")
+		endBattle(cfg)
+		blackOut(cfg)
+		evolveLeveledUp(cfg)
+	default:
+		if err := markSeen(cfg, opponents.Current().Species); err != nil {
+			fmt.Println("Could not look up the next pokemon:", err)
+		}
+		syncParty(cfg)
+		printBattleStatus(cfg)
+	}
+}
+
+// endBattle writes the party's HP, status and PP back to the caught pokemon
+// and ends the battle and the wild encounter or trainer battle.
+func endBattle(cfg *config) {
+	if cfg.battle != nil {
+		syncParty(cfg)
+	}
+	cfg.battle = nil
+	cfg.wild = nil
+	cfg.opponent = nil
+}
+
+func syncParty(cfg *config) {
+	for _, combatant := range cfg.battle.Sides[battle.Player].Team {
+		c, exists := cfg.pokedex.Get(combatant.ID)
+		if !exists {
+			continue
+		}
+		c.Damage = combatant.MaxHP() - combatant.HP
+		c.Status = combatant.Status
+		c.PPUsed = map[string]int{}
+		for _, slot := range combatant.Moves {
+			if used := slot.Move.PP - slot.PP; used > 0 {
+				c.PPUsed[slot.Move.Name] = used
+			}
+		}
+	}
+}
+
+// blackOut sends the player home, where their pokemon are nursed back to
+// health.
+func blackOut(cfg *config) {
+	cfg.location = cfg.world.Start
+	for _, c := range cfg.pokedex.PartyMembers() {
+		c.Heal()
+	}
+	fmt.Printf("You hurried back to %s, where your pokemon were nursed back to health.\n", cfg.location)
+}
+
+func printBattleStatus(cfg *config) {
+	for _, side := range cfg.battle.Sides {
+		c := side.Current()
+		fmt.Printf("  %s Lv. %d  HP %s %d/%d %s\n", c.Name, c.Level, hpBar(c.HP, c.MaxHP()), c.HP, c.MaxHP(), statusLabel(c.Status))
+	}
+}
+
+func statusLabel(status string) string {
+	labels := map[string]string{
+		battle.Sleep:     "SLP",
+		battle.Poison:    "PSN",
+		battle.Burn:      "BRN",
+		battle.Paralysis: "PAR",
+		battle.Freeze:    "FRZ",
+	}
+	return labels[status]
+}
+
+func hpBar(hp, maxHP int) string {
+	const width = 20
+	filled := 0
+	if maxHP > 0 {
+		filled = (hp*width + maxHP - 1) / maxHP
+	}
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", width-filled) + "]"
+}
+
+func partyCombatants(cfg *config) ([]*battle.Combatant, error) {
+	team := []*battle.Combatant{}
+	for _, c := range cfg.pokedex.PartyMembers() {
+		combatant, err := caughtCombatant(cfg, c)
+		if err != nil {
+			return nil, err
+		}
+		team = append(team, combatant)
+	}
+	return team, nil
+}
+
+func caughtCombatant(cfg *config, c *pokedex.Caught) (*battle.Combatant, error) {
+	pokemon := cfg.pokedex.Species[c.Species]
+	if len(c.Moves) == 0 {
+		c.Moves = pokedex.MovesAtLevel(pokemon, c.Level, cfg.versionGroup)
+	}
+	moves, err := loadMoves(cfg, c.Moves)
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range moves {
+		slot.PP = max(slot.Move.PP-c.PPUsed[slot.Move.Name], 0)
+	}
+	stats := c.Stats(pokemon)
+	combatant := &battle.Combatant{
+		ID:      c.ID,
+		Name:    c.Name(),
+		Species: c.Species,
+		Level:   c.Level,
+		Types:   pokemonTypes(pokemon),
+		Stats:   stats,
+		HP:      max(stats["hp"]-c.Damage, 0),
+		Moves:   moves,
+		Status:  c.Status,
+	}
+	if combatant.Status == battle.Sleep {
+		combatant.SleepTurns = 1 + cfg.rng.Intn(3)
+	}
+	return combatant, nil
+}
+
+func loadMoves(cfg *config, names []string) ([]*battle.MoveSlot, error) {
+	slots := []*battle.MoveSlot{}
+	for _, name := range names {
+		move, err := cfg.client.GetMove("https://pokeapi.co/api/v2/move/"+name, cfg.cache)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, &battle.MoveSlot{Move: move, PP: move.PP})
+	}
+	return slots, nil
+}
+
+func pokemonTypes(pokemon pokedex.Pokemon) []string {
+	types := make([]string, len(pokemon.Types))
+	for i := 0; i < len(pokemon.Types); i++ {
+		types[i] = pokemon.Types[i].Type.Name
+	}
+	return types
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func TestStartWildBattleWithoutHealthyPokemon(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	pidgey := pokedex.Pokemon{Name: "pidgey"}
+	c := cfg.pokedex.Catch(pidgey, 5, "kanto-route-1-area", time.Now())
+	c.Damage = c.Stats(pidgey)["hp"]
+	cfg.wild = &wildPokemon{combatant: &battle.Combatant{Name: "wild rattata", HP: 10}}
+
+	if err := startWildBattle(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.battle != nil {
+		t.Errorf("expected no battle when every party pokemon has fainted")
+	}
+}
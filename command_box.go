@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func commandNickname(cfg *config) error {
+	if len(cfg.args) < 2 {
+		fmt.Println("You must specify a pokemon ID and a nickname!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, cfg.args[0])
+	if err != nil {
+		return err
+	}
+	c.Nickname = strings.Join(cfg.args[1:], " ")
+	fmt.Printf("%s is now called %s.\n", c.Species, c.Nickname)
+	return nil
+}
+
+func commandRelease(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You can't release a pokemon in the middle of a battle!")
+		return errBattleInProgress
+	}
+	if len(cfg.args) != 1 {
+		fmt.Println("You must specify the ID of the pokemon to release!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, cfg.args[0])
+	if err != nil {
+		return err
+	}
+	if !confirm(cfg, fmt.Sprintf("Release #%d %s? You won't get it back.", c.ID, c.Name())) {
+		fmt.Println("You kept it.")
+		return nil
+	}
+	cfg.pokedex.Release(c.ID)
+	fmt.Printf("%s was released. Bye-bye, %s!\n", c.Name(), c.Name())
+	return nil
+}
+
+func commandBox(cfg *config) error {
+	if len(cfg.args) == 0 {
+		cfg.args = []string{"list"}
+	}
+	switch cfg.args[0] {
+	case "list":
+		return boxList(cfg, cfg.args[1:])
+	case "move":
+		if cfg.battle != nil {
+			fmt.Println("You can't move pokemon between boxes in the middle of a battle!")
+			return errBattleInProgress
+		}
+		return boxMove(cfg, cfg.args[1:])
+	case "rename":
+		return boxRename(cfg, cfg.args[1:])
+	}
+	fmt.Println("Usage: box list [box], box move <id> <box>, box rename <box> <name>")
+	return fmt.Errorf("unknown box command %s", cfg.args[0])
+}
+
+func boxList(cfg *config, args []string) error {
+	if len(args) == 0 {
+		for i, name := range cfg.pokedex.BoxNames() {
+			fmt.Printf("%d. %s (%d/%d)\n", i+1, name, len(cfg.pokedex.BoxContents(i)), pokedex.BoxCapacity)
+		}
+		return nil
+	}
+	box, err := cfg.pokedex.FindBox(strings.Join(args, " "))
+	if err != nil {
+		fmt.Println("That box does not exist.")
+		return err
+	}
+	fmt.Printf("%s:\n", cfg.pokedex.Boxes[box])
+	printCaughtList(cfg.pokedex.BoxContents(box))
+	return nil
+}
+
+func boxMove(cfg *config, args []string) error {
+	if len(args) < 2 {
+		fmt.Println("You must specify a pokemon ID and a box!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	box, err := cfg.pokedex.FindBox(strings.Join(args[1:], " "))
+	if err != nil {
+		fmt.Println("That box does not exist.")
+		return err
+	}
+	err = cfg.pokedex.MoveToBox(c.ID, box)
+	if errors.Is(err, pokedex.ErrBoxFull) {
+		fmt.Printf("%s is full.\n", cfg.pokedex.Boxes[box])
+		return err
+	}
+	fmt.Printf("%s was moved to %s.\n", c.Name(), cfg.pokedex.Boxes[box])
+	return nil
+}
+
+func boxRename(cfg *config, args []string) error {
+	if len(args) < 2 {
+		fmt.Println("You must specify a box and its new name!")
+		return errors.New("missing argument")
+	}
+	box, err := cfg.pokedex.FindBox(args[0])
+	if err != nil {
+		fmt.Println("That box does not exist.")
+		return err
+	}
+	old := cfg.pokedex.Boxes[box]
+	cfg.pokedex.RenameBox(box, strings.Join(args[1:], " "))
+	fmt.Printf("%s is now called %s.\n", old, cfg.pokedex.Boxes[box])
+	return nil
+}
+
+func caughtByID(cfg *config, arg string) (*pokedex.Caught, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Println("You must use the pokemon's ID. Use pokedex to see them.")
+		return nil, err
+	}
+	c, exists := cfg.pokedex.Get(id)
+	if !exists {
+		fmt.Printf("You don't have a pokemon with ID %d.\n", id)
+		return nil, pokedex.ErrNoSuchPokemon
+	}
+	return c, nil
+}
+
+// confirm asks a yes/no question on the REPL's input and reports whether the
+// player answered yes.
+func confirm(cfg *config, question string) bool {
+	fmt.Printf("%s (y/n) ", question)
+	if !cfg.scanner.Scan() {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(cfg.scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
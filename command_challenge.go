@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+	"github.com/samersawan/pokedexcli/internal/trainer"
+)
+
+func commandChallenge(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You're in the middle of a battle!")
+		return errBattleInProgress
+	}
+	if len(cfg.args) == 0 {
+		trainers := cfg.trainers.At(cfg.location)
+		if len(trainers) == 0 {
+			fmt.Printf("There are no trainers around %s.\n", cfg.location)
+			return nil
+		}
+		fmt.Printf("Trainers in %s:\n", cfg.location)
+		for _, t := range trainers {
+			defeated := ""
+			if contains(cfg.defeated, t.Name) {
+				defeated = " (defeated)"
+			}
+			fmt.Printf(" - %s: %s%s\n", t.Name, t.Title, defeated)
+		}
+		return nil
+	}
+
+	t, exists := cfg.trainers.Get(cfg.args[0])
+	if !exists || t.Location != cfg.location {
+		fmt.Printf("There is no trainer called %s here. Use challenge to see who is around.\n", cfg.args[0])
+		return errors.New("unknown trainer")
+	}
+	if contains(cfg.defeated, t.Name) {
+		fmt.Printf("You have already defeated %s.\n", t.Title)
+		return errors.New("trainer already defeated")
+	}
+	for _, badge := range t.RequiresBadges {
+		if !contains(cfg.badges, badge) {
+			fmt.Printf("%s won't battle you until you have the %s.\n", t.Title, badge)
+			return errors.New("missing badge")
+		}
+	}
+
+	party, err := partyCombatants(cfg)
+	if err != nil {
+		return err
+	}
+	if len(party) == 0 || battle.NewSide("You", party).Defeated() {
+		fmt.Println("You have no pokemon that can fight! Heal your party first.")
+		return errors.New("no pokemon can fight")
+	}
+	team, err := trainerCombatants(cfg, t)
+	if err != nil {
+		return err
+	}
+
+	cfg.wild = nil
+	cfg.opponent = &t
+	cfg.battle = battle.New(
+		battle.NewSide("You", party),
+		battle.NewSide(t.Title, team),
+		cfg.rng,
+	)
+	opponent := cfg.battle.Sides[battle.Opponent].Current()
+	if err := markSeen(cfg, opponent.Species); err != nil {
+		return err
+	}
+	fmt.Printf("%s wants to battle!\n", t.Title)
+	fmt.Printf("%s sent out %s!\n", t.Title, opponent.Name)
+	fmt.Printf("Go! %s!\n", cfg.battle.Sides[battle.Player].Current().Name)
+	printBattleStatus(cfg)
+	return nil
+}
+
+// trainerCombatants builds a trainer's team. Members without a move list
+// know the latest moves they learn by leveling up.
+func trainerCombatants(cfg *config, t trainer.Trainer) ([]*battle.Combatant, error) {
+	team := []*battle.Combatant{}
+	for _, member := range t.Team {
+		pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+member.Species, cfg.cache)
+		if err != nil {
+			return nil, err
+		}
+		names := member.Moves
+		if len(names) == 0 {
+			names = pokedex.MovesAtLevel(pokemon, member.Level, cfg.versionGroup)
+		}
+		moves, err := loadMoves(cfg, names)
+		if err != nil {
+			return nil, err
+		}
+
+		ivs := pokedex.Stats{}
+		for _, name := range pokedex.StatNames {
+			ivs[name] = t.IV()
+		}
+		stats := pokedex.ComputeStats(pokemon, ivs, pokedex.Stats{}, member.Level, pokedex.Nature{})
+		team = append(team, &battle.Combatant{
+			Name:    pokemon.Name,
+			Species: pokemon.Name,
+			Level:   member.Level,
+			Types:   pokemonTypes(pokemon),
+			Stats:   stats,
+			HP:      stats["hp"],
+			Moves:   moves,
+		})
+	}
+	return team, nil
+}
+
+// trainerDefeated pays out the prize money of a won trainer battle and hands
+// over the gym leader's badge.
+func trainerDefeated(cfg *config, t *trainer.Trainer) {
+	cfg.defeated = append(cfg.defeated, t.Name)
+	cfg.inventory.Money += t.Reward
+	fmt.Printf("You defeated %s and got $%d for winning!\n", t.Title, t.Reward)
+	if t.Badge != "" && !contains(cfg.badges, t.Badge) {
+		cfg.badges = append(cfg.badges, t.Badge)
+		fmt.Printf("You received the %s!\n", t.Badge)
+	}
+}
+
+func commandBadges(cfg *config) error {
+	if len(cfg.badges) == 0 {
+		fmt.Println("You have no badges yet. Challenge a gym leader to earn one!")
+		return nil
+	}
+	fmt.Printf("You have %d badges:\n", len(cfg.badges))
+	for _, badge := range cfg.badges {
+		fmt.Printf(" - %s\n", badge)
+	}
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+	"github.com/samersawan/pokedexcli/internal/statindex"
+)
+
+// compared is a species or caught pokemon in a comparison. Caught pokemon
+// are compared by their actual stats and species by their base stats.
+type compared struct {
+	label   string
+	pokemon pokedex.Pokemon
+	caught  *pokedex.Caught
+	stats   pokedex.Stats
+}
+
+func commandCompare(cfg *config) error {
+	if len(cfg.args) < 2 {
+		fmt.Println("You must specify at least two pokemon to compare!")
+		return errors.New("missing argument")
+	}
+	entries := []compared{}
+	for _, arg := range cfg.args {
+		entry, err := resolveCompared(cfg, arg)
+		if err != nil {
+			fmt.Printf("Could not find %s: %v\n", arg, err)
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(name string, cells []string) {
+		fmt.Fprintf(w, " %s\t%s\n", name, strings.Join(cells, "\t"))
+	}
+	row("", mapCompared(entries, func(e compared) string { return e.label }))
+	row("height", mapCompared(entries, func(e compared) string { return fmt.Sprintf("%.1f m", float64(e.pokemon.Height)/10) }))
+	row("weight", mapCompared(entries, func(e compared) string { return fmt.Sprintf("%.1f kg", float64(e.pokemon.Weight)/10) }))
+	row("types", mapCompared(entries, func(e compared) string { return strings.Join(pokemonTypes(e.pokemon), "/") }))
+	row("abilities", mapCompared(entries, func(e compared) string { return strings.Join(abilities(e.pokemon), ", ") }))
+	for _, name := range append(append([]string{}, pokedex.StatNames...), statindex.Total) {
+		values := []int{}
+		for _, e := range entries {
+			if name == statindex.Total {
+				values = append(values, statindex.TotalOf(e.stats))
+			} else {
+				values = append(values, e.stats[name])
+			}
+		}
+		cells := []string{}
+		for i, best := range statWinners(values) {
+			cell := strconv.Itoa(values[i])
+			if best {
+				cell += " *"
+			}
+			cells = append(cells, cell)
+		}
+		row(name, cells)
+	}
+	w.Flush()
+	fmt.Println("* marks the best value of each stat. Caught pokemon are compared by their actual stats, species by their base stats.")
+
+	fmt.Println()
+	fmt.Println("Type matchups:")
+	for i, attacker := range entries {
+		for j, defender := range entries {
+			if i == j {
+				continue
+			}
+			defending := pokemonTypes(defender.pokemon)
+			hits := []string{}
+			for _, t := range pokemonTypes(attacker.pokemon) {
+				hits = append(hits, fmt.Sprintf("%s %gx", t, battle.Effectiveness(t, defending)))
+			}
+			fmt.Printf(" %s -> %s: %s\n", attacker.label, defender.label, strings.Join(hits, ", "))
+		}
+	}
+
+	fmt.Println()
+	printSpeedTiers(entries)
+	return nil
+}
+
+// resolveCompared looks arguments up the way inspect does: a single caught
+// pokemon matching the ID, nickname or species is compared as that pokemon,
+// several caught of one species as the species, and anything else as the
+// species fetched by name.
+func resolveCompared(cfg *config, arg string) (compared, error) {
+	found := cfg.pokedex.Find(arg)
+	if len(found) == 1 {
+		caught := found[0]
+		pokemon := cfg.pokedex.Species[caught.Species]
+		return compared{
+			label:   fmt.Sprintf("#%d %s Lv. %d", caught.ID, caught.Name(), caught.Level),
+			pokemon: pokemon,
+			caught:  caught,
+			stats:   caught.Stats(pokemon),
+		}, nil
+	}
+	if len(found) > 1 {
+		pokemon := cfg.pokedex.Species[found[0].Species]
+		return compared{label: pokemon.Name, pokemon: pokemon, stats: pokedex.BaseStats(pokemon)}, nil
+	}
+	if _, err := strconv.Atoi(arg); err == nil {
+		return compared{}, pokedex.ErrNoSuchPokemon
+	}
+
+	pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+arg, cfg.cache)
+	if err != nil {
+		return compared{}, err
+	}
+	return compared{label: pokemon.Name, pokemon: pokemon, stats: pokedex.BaseStats(pokemon)}, nil
+}
+
+func mapCompared(entries []compared, f func(compared) string) []string {
+	cells := make([]string, len(entries))
+	for i, e := range entries {
+		cells[i] = f(e)
+	}
+	return cells
+}
+
+func abilities(pokemon pokedex.Pokemon) []string {
+	names := []string{}
+	for _, a := range pokemon.Abilities {
+		name := a.Ability.Name
+		if a.IsHidden {
+			name += " (hidden)"
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// statWinners marks the highest of the values. Ties all win.
+func statWinners(values []int) []bool {
+	best := 0
+	for i, v := range values {
+		if i == 0 || v > best {
+			best = v
+		}
+	}
+	winners := make([]bool, len(values))
+	for i, v := range values {
+		winners[i] = v == best
+	}
+	return winners
+}
+
+// speedRange returns the lowest and highest speed a species can have at a
+// level: no IVs or EVs with a hindering nature, up to perfect IVs, maximum
+// EVs and a boosting nature.
+func speedRange(base, level int) (int, int) {
+	hindering := pokedex.Nature{Increased: "attack", Decreased: "speed"}
+	boosting := pokedex.Nature{Increased: "speed", Decreased: "attack"}
+	return pokedex.ComputeStat("speed", base, 0, 0, level, hindering), pokedex.ComputeStat("speed", base, 31, 252, level, boosting)
+}
+
+// printSpeedTiers ranks the pokemon from fastest to slowest at level 100.
+// Species are ranked by the range of speeds they can have and caught pokemon
+// by the speed their IVs, EVs and nature give them.
+func printSpeedTiers(entries []compared) {
+	type tier struct {
+		label    string
+		low      int
+		high     int
+		describe string
+	}
+	tiers := []tier{}
+	for _, e := range entries {
+		if e.caught != nil {
+			base := pokedex.BaseStats(e.pokemon)["speed"]
+			speed := pokedex.ComputeStat("speed", base, e.caught.IVs["speed"], e.caught.EVs["speed"], 100, e.caught.Nature)
+			tiers = append(tiers, tier{label: e.label, low: speed, high: speed, describe: fmt.Sprintf("%d at Lv. 100, %d now", speed, e.stats["speed"])})
+			continue
+		}
+		low, high := speedRange(e.stats["speed"], 100)
+		tiers = append(tiers, tier{label: e.label, low: low, high: high, describe: fmt.Sprintf("base %d, %d-%d at Lv. 100", e.stats["speed"], low, high)})
+	}
+	sort.SliceStable(tiers, func(i, j int) bool {
+		return tiers[i].high > tiers[j].high
+	})
+
+	fmt.Println("Speed tiers:")
+	for i, t := range tiers {
+		fmt.Printf(" %d. %s (%s)\n", i+1, t.label, t.describe)
+	}
+	for i := 0; i+1 < len(tiers); i++ {
+		faster, slower := tiers[i], tiers[i+1]
+		switch {
+		case faster.low > slower.high:
+			fmt.Printf(" %s always outspeeds %s.\n", faster.label, slower.label)
+		case faster.low == slower.low && faster.high == slower.high:
+			fmt.Printf(" %s and %s are in the same speed tier.\n", faster.label, slower.label)
+		default:
+			fmt.Printf(" %s can outspeed %s, depending on IVs, EVs and nature.\n", faster.label, slower.label)
+		}
+	}
+}
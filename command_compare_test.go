@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func TestStatWinners(t *testing.T) {
+	cases := []struct {
+		values   []int
+		expected []bool
+	}{
+		{values: []int{45, 60}, expected: []bool{false, true}},
+		{values: []int{80, 80, 20}, expected: []bool{true, true, false}},
+		{values: []int{0, 0}, expected: []bool{true, true}},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if got := statWinners(c.values); fmt.Sprint(got) != fmt.Sprint(c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestSpeedRange(t *testing.T) {
+	// Garchomp's base 102 speed gives 188 to 333 at level 100.
+	low, high := speedRange(102, 100)
+	if low != 188 || high != 333 {
+		t.Errorf("expected 188-333, got %d-%d", low, high)
+	}
+}
+
+func TestResolveCompared(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Catch(pokedex.Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	cfg.pokedex.Catch(pokedex.Pokemon{Name: "rattata"}, 2, "kanto-route-1-area", time.Now())
+	ratty := cfg.pokedex.Catch(pokedex.Pokemon{Name: "rattata"}, 4, "kanto-route-1-area", time.Now())
+	ratty.Nickname = "ratty"
+
+	cases := []struct {
+		arg      string
+		label    string
+		caughtID int
+	}{
+		{arg: "1", label: "#1 pidgey Lv. 3", caughtID: 1},
+		{arg: "pidgey", label: "#1 pidgey Lv. 3", caughtID: 1},
+		{arg: "ratty", label: "#3 ratty Lv. 4", caughtID: 3},
+		{arg: "rattata", label: "rattata"},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			entry, err := resolveCompared(cfg, c.arg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.label != c.label {
+				t.Errorf("expected label %q, got %q", c.label, entry.label)
+			}
+			id := 0
+			if entry.caught != nil {
+				id = entry.caught.ID
+			}
+			if id != c.caughtID {
+				t.Errorf("expected caught pokemon %d, got %d", c.caughtID, id)
+			}
+		})
+	}
+
+	if _, err := resolveCompared(cfg, "9"); !errors.Is(err, pokedex.ErrNoSuchPokemon) {
+		t.Errorf("expected ErrNoSuchPokemon for an unknown ID, got %v", err)
+	}
+}
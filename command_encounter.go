@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+type wildPokemon struct {
+	pokemon pokedex.Pokemon
+	species pokedex.Species
+	level   int
+	method  string
+	ivs     pokedex.Stats
+	nature  pokedex.Nature
+	shiny   bool
+	form    pokedex.Form
+
+	combatant *battle.Combatant
+}
+
+func commandEncounter(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You're in the middle of a battle!")
+		return errBattleInProgress
+	}
+	_, flags := parseArgs(cfg.args)
+	encounters, err := exploreCurrentArea(cfg)
+	if err != nil {
+		return err
+	}
+	versions := []string{}
+	if version, ok := flags["version"]; ok {
+		versions = append(versions, version)
+	} else {
+		versions, err = cfg.client.GetVersionGroupVersions("https://pokeapi.co/api/v2/version-group/"+cfg.versionGroup, cfg.cache)
+		if err != nil {
+			fmt.Printf("Could not look up the versions of %s: %v\n", cfg.versionGroup, err)
+			return err
+		}
+	}
+	encounters = filterVersions(encounters, versions)
+	if len(encounters) == 0 {
+		fmt.Printf("There are no wild Pokemon around %s in %s.\n", cfg.location, strings.Join(versions, " or "))
+		return errors.New("no wild pokemon")
+	}
+
+	method, ok := flags["method"]
+	if !ok {
+		method = "walk"
+	}
+	candidates := filterMethod(encounters, method)
+	if len(candidates) == 0 {
+		fmt.Printf("You can't find any Pokemon by %s here. Try --method with one of: %s\n", method, strings.Join(methods(encounters), ", "))
+		return errors.New("no encounters for method")
+	}
+
+	e := rollEncounter(rollVersion(candidates, cfg.rng), cfg.rng)
+	level := e.MinLevel + cfg.rng.Intn(e.MaxLevel-e.MinLevel+1)
+	pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+e.Pokemon, cfg.cache)
+	if err != nil {
+		return err
+	}
+
+	species, err := cfg.client.GetPokemonSpecies(pokemon.Species.URL, cfg.cache)
+	if err != nil {
+		return err
+	}
+
+	nature, err := rollNature(cfg)
+	if err != nil {
+		return err
+	}
+
+	form, err := rollForm(cfg, pokemon)
+	if err != nil {
+		return err
+	}
+
+	ivs := pokedex.RollIVs(cfg.rng)
+	stats := pokedex.ComputeStats(pokemon, ivs, pokedex.Stats{}, level, nature)
+	moves, err := loadMoves(cfg, pokedex.MovesAtLevel(pokemon, level, cfg.versionGroup))
+	if err != nil {
+		return err
+	}
+
+	cfg.wild = &wildPokemon{
+		pokemon: pokemon,
+		species: species,
+		level:   level,
+		method:  method,
+		ivs:     ivs,
+		nature:  nature,
+		shiny:   pokedex.RollShiny(cfg.rng, cfg.shinyOdds),
+		form:    form,
+		combatant: &battle.Combatant{
+			Name:    "wild " + pokemon.Name,
+			Species: pokemon.Name,
+			Level:   level,
+			Types:   pokemonTypes(pokemon),
+			Stats:   stats,
+			HP:      stats["hp"],
+			Moves:   moves,
+		},
+	}
+	cfg.pokedex.MarkSeen(pokemon)
+	formName := ""
+	if form.FormName != "" {
+		formName = form.Name
+	}
+	printSprite(cfg, pokemon, formName, cfg.wild.shiny, spriteVersion(cfg, flags))
+	fmt.Printf("A wild %s (Lv. %d) appeared!\n", pokemon.Name, level)
+	if cfg.wild.shiny {
+		fmt.Println("*sparkle* It's shiny!")
+	}
+	if form.FormName != "" {
+		fmt.Printf("It's in its %s form.\n", form.FormName)
+	}
+	return startWildBattle(cfg)
+}
+
+func rollNature(cfg *config) (pokedex.Nature, error) {
+	natures, err := cfg.client.GetNatures("https://pokeapi.co/api/v2/nature/?limit=25", cfg.cache)
+	if err != nil {
+		return pokedex.Nature{}, err
+	}
+	name := natures[cfg.rng.Intn(len(natures))]
+	return cfg.client.GetNature("https://pokeapi.co/api/v2/nature/"+name, cfg.cache)
+}
+
+// rollForm picks one of the pokemon's forms, like one of Unown's letters.
+// Battle-only forms and megas are never found in the wild.
+func rollForm(cfg *config, pokemon pokedex.Pokemon) (pokedex.Form, error) {
+	forms := []pokedex.Form{}
+	for _, f := range pokemon.Forms {
+		form, err := cfg.client.GetPokemonForm(f.URL, cfg.cache)
+		if err != nil {
+			return pokedex.Form{}, err
+		}
+		if form.Playable() {
+			forms = append(forms, form)
+		}
+	}
+	if len(forms) == 0 {
+		return pokedex.Form{}, nil
+	}
+	return forms[cfg.rng.Intn(len(forms))], nil
+}
+
+// rollEncounter picks one of the encounters with a probability proportional
+// to its chance, the way the games pick an encounter slot.
+func rollEncounter(encounters []api.Encounter, r *rand.Rand) api.Encounter {
+	total := 0
+	for _, e := range encounters {
+		total += e.Chance
+	}
+	if total <= 0 {
+		return encounters[r.Intn(len(encounters))]
+	}
+	roll := r.Intn(total)
+	for _, e := range encounters {
+		if roll < e.Chance {
+			return e
+		}
+		roll -= e.Chance
+	}
+	return encounters[len(encounters)-1]
+}
+
+func filterVersions(encounters []api.Encounter, versions []string) []api.Encounter {
+	filtered := []api.Encounter{}
+	for _, e := range encounters {
+		if contains(versions, e.Version) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// rollVersion picks one of the versions the encounters come from and returns
+// its encounters. Each version's chances add up on their own, so rolling
+// across several versions at once would favour pokemon found in all of them.
+func rollVersion(encounters []api.Encounter, r *rand.Rand) []api.Encounter {
+	versions := []string{}
+	for _, e := range encounters {
+		if !contains(versions, e.Version) {
+			versions = append(versions, e.Version)
+		}
+	}
+	sort.Strings(versions)
+	return filterVersion(encounters, versions[r.Intn(len(versions))])
+}
+
+func filterMethod(encounters []api.Encounter, method string) []api.Encounter {
+	filtered := []api.Encounter{}
+	for _, e := range encounters {
+		if e.Method == method {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+func methods(encounters []api.Encounter) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, e := range encounters {
+		if !seen[e.Method] {
+			seen[e.Method] = true
+			names = append(names, e.Method)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
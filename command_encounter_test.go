@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+)
+
+func TestRollEncounterIsSeeded(t *testing.T) {
+	encounters := []api.Encounter{
+		{Pokemon: "pidgey", Chance: 50},
+		{Pokemon: "rattata", Chance: 45},
+		{Pokemon: "mewtwo", Chance: 5},
+	}
+	first := rand.New(rand.NewSource(7))
+	second := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		a, b := rollEncounter(encounters, first), rollEncounter(encounters, second)
+		if a.Pokemon != b.Pokemon {
+			t.Fatalf("expected the same encounters from the same seed, got %s and %s on roll %d", a.Pokemon, b.Pokemon, i)
+		}
+	}
+}
+
+func TestRollEncounterSkipsZeroChance(t *testing.T) {
+	encounters := []api.Encounter{
+		{Pokemon: "pidgey", Chance: 100},
+		{Pokemon: "mewtwo", Chance: 0},
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if e := rollEncounter(encounters, r); e.Pokemon != "pidgey" {
+			t.Fatalf("expected only pidgey, got %s", e.Pokemon)
+		}
+	}
+}
+
+func TestRollVersion(t *testing.T) {
+	encounters := []api.Encounter{
+		{Pokemon: "pidgey", Version: "red", Chance: 50},
+		{Pokemon: "ekans", Version: "red", Chance: 50},
+		{Pokemon: "pidgey", Version: "blue", Chance: 50},
+		{Pokemon: "sandshrew", Version: "blue", Chance: 50},
+	}
+	r := rand.New(rand.NewSource(1))
+	rolled := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		picked := rollVersion(encounters, r)
+		if len(picked) != 2 || picked[0].Version != picked[1].Version {
+			t.Fatalf("expected the encounters of one version, got %+v", picked)
+		}
+		rolled[picked[0].Version] = true
+	}
+	if !rolled["red"] || !rolled["blue"] {
+		t.Errorf("expected both versions to be rolled, got %v", rolled)
+	}
+}
+
+func TestFilterVersions(t *testing.T) {
+	encounters := []api.Encounter{
+		{Pokemon: "pidgey", Version: "red", Method: "walk"},
+		{Pokemon: "pidgey", Version: "gold", Method: "headbutt"},
+		{Pokemon: "pidgey", Version: "blue", Method: "walk"},
+	}
+	filtered := filterVersions(encounters, []string{"red", "blue"})
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 encounters, got %d", len(filtered))
+	}
+	if got := methods(filtered); len(got) != 1 || got[0] != "walk" {
+		t.Errorf("expected only walk from red and blue, got %v", got)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/samersawan/pokedexcli/internal/statindex"
+)
+
+const indexWorkers = 8
+
+// commandIndex downloads the base stats of every species into a local index
+// that inspect ranks stats against.
+func commandIndex(cfg *config) error {
+	names, err := cfg.client.GetPokemonNames("https://pokeapi.co/api/v2/pokemon/?limit=100000", cfg.cache)
+	if err != nil {
+		fmt.Println("Could not list the pokemon:", err)
+		return err
+	}
+	fmt.Printf("Indexing the base stats of %d pokemon. This takes a while...\n", len(names))
+
+	idx := statindex.Index{}
+	failed := 0
+	done := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	for w := 0; w < indexWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+name, cfg.cache)
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					idx.Add(pokemon)
+				}
+				done++
+				if done%100 == 0 {
+					fmt.Printf("  %d/%d\n", done, len(names))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := statindex.Write(cfg.statIndexPath, idx); err != nil {
+		fmt.Println("Could not save the stat index:", err)
+		return err
+	}
+	cfg.statIndex = idx
+	fmt.Printf("Indexed %d species.\n", len(idx.Pokemon))
+	if failed > 0 {
+		fmt.Printf("%d pokemon could not be fetched. Run index again to retry.\n", failed)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func commandParty(cfg *config) error {
+	if len(cfg.args) == 0 {
+		members := cfg.pokedex.PartyMembers()
+		if len(members) == 0 {
+			fmt.Println("Your party is empty.")
+			return nil
+		}
+		fmt.Printf("Your Party (%d/%d):\n", len(members), pokedex.MaxPartySize)
+		for _, c := range members {
+			maxHP := c.Stats(cfg.pokedex.Species[c.Species])["hp"]
+			hp := max(maxHP-c.Damage, 0)
+			condition := statusLabel(c.Status)
+			if hp == 0 {
+				condition = "FNT"
+			}
+			fmt.Printf(" - #%d %s Lv. %d  HP %d/%d %s\n", c.ID, c.Name(), c.Level, hp, maxHP, condition)
+		}
+		return nil
+	}
+	if cfg.battle != nil {
+		fmt.Println("You can't change your party in the middle of a battle! Use switch to send out another pokemon.")
+		return errBattleInProgress
+	}
+
+	switch cfg.args[0] {
+	case "add":
+		return partyAdd(cfg, cfg.args[1:])
+	case "remove":
+		return partyRemove(cfg, cfg.args[1:])
+	case "swap":
+		return partySwap(cfg, cfg.args[1:])
+	}
+	fmt.Println("Usage: party, party add <id>, party remove <id>, party swap <a> <b>")
+	return fmt.Errorf("unknown party command %s", cfg.args[0])
+}
+
+func partyAdd(cfg *config, args []string) error {
+	if len(args) != 1 {
+		fmt.Println("You must specify the ID of the pokemon to add!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	err = cfg.pokedex.AddToParty(c.ID)
+	if errors.Is(err, pokedex.ErrPartyFull) {
+		fmt.Println("Your party is full. Use party remove to make room.")
+		return err
+	}
+	if errors.Is(err, pokedex.ErrInParty) {
+		fmt.Printf("%s is already in your party.\n", c.Name())
+		return err
+	}
+	fmt.Printf("%s joined your party.\n", c.Name())
+	return nil
+}
+
+func partyRemove(cfg *config, args []string) error {
+	if len(args) != 1 {
+		fmt.Println("You must specify the ID of the pokemon to remove!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	err = cfg.pokedex.RemoveFromParty(c.ID)
+	if errors.Is(err, pokedex.ErrNotInParty) {
+		fmt.Printf("%s is not in your party.\n", c.Name())
+		return err
+	}
+	fmt.Printf("%s was sent to %s.\n", c.Name(), cfg.pokedex.Boxes[c.Box])
+	return nil
+}
+
+func partySwap(cfg *config, args []string) error {
+	if len(args) != 2 {
+		fmt.Println("You must specify the IDs of the two pokemon to swap!")
+		return errors.New("missing argument")
+	}
+	a, err := caughtByID(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	b, err := caughtByID(cfg, args[1])
+	if err != nil {
+		return err
+	}
+	if err := cfg.pokedex.SwapParty(a.ID, b.ID); err != nil {
+		fmt.Println("Both pokemon must be in your party.")
+		return err
+	}
+	fmt.Printf("%s and %s swapped places.\n", a.Name(), b.Name())
+	return nil
+}
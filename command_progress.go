@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+func commandProgress(cfg *config) error {
+	generations, err := cfg.client.GetGenerations("https://pokeapi.co/api/v2/generation/", cfg.cache)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Pokedex progress by generation:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " GENERATION\tSEEN\tCAUGHT")
+	totalSeen, totalCaught, total := 0, 0, 0
+	for _, name := range generations {
+		generation, err := cfg.client.GetGeneration("https://pokeapi.co/api/v2/generation/"+name, cfg.cache)
+		if err != nil {
+			return err
+		}
+		seen, caught := dexCounts(cfg, generation.Species)
+		fmt.Fprintf(w, " %s\t%s\t%s\n", generation.Name, percentage(seen, len(generation.Species)), percentage(caught, len(generation.Species)))
+		totalSeen += seen
+		totalCaught += caught
+		total += len(generation.Species)
+	}
+	fmt.Fprintf(w, " total\t%s\t%s\n", percentage(totalSeen, total), percentage(totalCaught, total))
+	w.Flush()
+
+	area, _ := cfg.world.Area(cfg.location)
+	region, err := cfg.client.GetRegion("https://pokeapi.co/api/v2/region/"+area.Region, cfg.cache)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("Regional Pokedexes of %s:\n", region.Name)
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " POKEDEX\tSEEN\tCAUGHT")
+	missing := []string{}
+	for i, name := range region.Pokedexes {
+		dex, err := cfg.client.GetPokedex("https://pokeapi.co/api/v2/pokedex/"+name, cfg.cache)
+		if err != nil {
+			return err
+		}
+		seen, caught := dexCounts(cfg, dex.Species)
+		fmt.Fprintf(w, " %s\t%s\t%s\n", dex.Name, percentage(seen, len(dex.Species)), percentage(caught, len(dex.Species)))
+		if i == 0 {
+			for _, species := range dex.Species {
+				if !cfg.pokedex.HasCaught(species) {
+					missing = append(missing, species)
+				}
+			}
+		}
+	}
+	w.Flush()
+
+	if len(region.Pokedexes) > 0 {
+		fmt.Println()
+		if len(missing) == 0 {
+			fmt.Printf("You have caught every pokemon in the %s Pokedex!\n", region.Pokedexes[0])
+			return nil
+		}
+		fmt.Printf("Still missing from the %s Pokedex:\n", region.Pokedexes[0])
+		for _, species := range missing {
+			marker := " "
+			if cfg.pokedex.HasSeen(species) {
+				marker = "*"
+			}
+			fmt.Printf(" %s %s\n", marker, species)
+		}
+		fmt.Println("(* seen but not caught)")
+	}
+	return nil
+}
+
+func dexCounts(cfg *config, species []string) (int, int) {
+	seen, caught := 0, 0
+	for _, name := range species {
+		if cfg.pokedex.HasSeen(name) {
+			seen++
+		}
+		if cfg.pokedex.HasCaught(name) {
+			caught++
+		}
+	}
+	return seen, caught
+}
+
+func percentage(n, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%.1f%%)", n, total, 100*float64(n)/float64(total))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+)
+
+const locationsPerPage = 20
+
+func commandRegions(cfg *config) error {
+	regions, err := cfg.client.GetRegions("https://pokeapi.co/api/v2/region/", cfg.cache)
+	if err != nil {
+		return err
+	}
+	for _, region := range regions {
+		fmt.Println(region)
+	}
+	return nil
+}
+
+func selectRegion(cfg *config, name string) error {
+	if name == "all" || name == "true" {
+		cfg.region = ""
+		cfg.regionLocations = nil
+		cfg.regionOffset = 0
+		fmt.Println("Showing location areas from every region. Use map to continue.")
+		return nil
+	}
+	region, err := cfg.client.GetRegion("https://pokeapi.co/api/v2/region/"+name, cfg.cache)
+	if err != nil {
+		fmt.Printf("Could not find region %s. Use regions to list them.\n", name)
+		return err
+	}
+	cfg.region = region.Name
+	cfg.regionLocations = region.Locations
+	cfg.regionOffset = 0
+	return showRegionPage(cfg, 0)
+}
+
+func showRegionPage(cfg *config, offset int) error {
+	if offset >= len(cfg.regionLocations) {
+		fmt.Printf("There are no more locations in %s. Use mapb to go back.\n", cfg.region)
+		return fmt.Errorf("no more locations in %s", cfg.region)
+	}
+	cfg.regionOffset = offset
+
+	end := min(offset+locationsPerPage, len(cfg.regionLocations))
+	fmt.Printf("Locations in %s (%d-%d of %d):\n", cfg.region, offset+1, end, len(cfg.regionLocations))
+	for _, name := range cfg.regionLocations[offset:end] {
+		location, err := cfg.client.GetLocation("https://pokeapi.co/api/v2/location/"+name, cfg.cache)
+		if err != nil {
+			return err
+		}
+		fmt.Println(location.Name)
+		for _, area := range location.Areas {
+			fmt.Printf("  - %s\n", area)
+		}
+	}
+	return nil
+}
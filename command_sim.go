@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokecache"
+	"github.com/samersawan/pokedexcli/internal/sim"
+	"github.com/samersawan/pokedexcli/internal/trainer"
+)
+
+const simUsage = "Usage: pokedexcli sim teamA.json teamB.json [--runs 1000] [--seed n] [--game red-blue]"
+
+// runSim battles two teams against each other many times without the REPL.
+// Team files use the same format as a trainer in the bundled trainer data.
+func runSim(args []string) error {
+	files, flags := parseArgs(args)
+	if len(files) != 2 {
+		fmt.Println(simUsage)
+		return errors.New("expected two team files")
+	}
+	runs := 1000
+	if value, ok := flags["runs"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			fmt.Println("--runs must be a positive number.")
+			return errors.New("invalid runs")
+		}
+		runs = n
+	}
+	seed := time.Now().UnixNano()
+	if value, ok := flags["seed"]; ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			fmt.Println("--seed must be a number.")
+			return errors.New("invalid seed")
+		}
+		seed = n
+	}
+
+	cfg := &config{
+		cache:        pokecache.NewCache(5 * time.Second),
+		client:       api.NewClient(5 * time.Second),
+		versionGroup: defaultVersionGroup,
+		rng:          rand.New(rand.NewSource(seed)),
+		seed:         seed,
+	}
+	if game, ok := flags["game"]; ok {
+		cfg.versionGroup = game
+	}
+
+	teams := [2]sim.Team{}
+	for i, path := range files {
+		t, err := loadTeam(path)
+		if err != nil {
+			fmt.Printf("Could not read team %s: %v\n", path, err)
+			return err
+		}
+		members, err := trainerCombatants(cfg, t)
+		if err != nil {
+			fmt.Printf("Could not build team %s: %v\n", path, err)
+			return err
+		}
+		if len(members) == 0 {
+			fmt.Printf("Team %s has no pokemon.\n", path)
+			return errors.New("empty team")
+		}
+		teams[i] = sim.Team{Name: t.Name, Strategy: t.Strategy(), Members: members}
+		if teams[i].Name == "" {
+			teams[i].Name = path
+		}
+	}
+
+	fmt.Printf("Simulating %d battles between %s and %s (seed %d)...\n", runs, teams[0].Name, teams[1].Name, seed)
+	result := sim.Run(teams[0], teams[1], runs, runtime.NumCPU(), seed)
+	printSimResult(teams, result)
+	return nil
+}
+
+func loadTeam(path string) (trainer.Trainer, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return trainer.Trainer{}, err
+	}
+	t := trainer.Trainer{}
+	err = json.Unmarshal(dat, &t)
+	if err != nil {
+		return trainer.Trainer{}, err
+	}
+	if _, known := battle.Strategies[t.AI]; t.AI != "" && !known {
+		return trainer.Trainer{}, fmt.Errorf("unknown ai %s", t.AI)
+	}
+	return t, nil
+}
+
+func printSimResult(teams [2]sim.Team, result sim.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " TEAM\tWINS\tWIN RATE")
+	for i, team := range teams {
+		fmt.Fprintf(w, " %s\t%d\t%.1f%%\n", team.Name, result.Wins[i], 100*result.WinRate(i))
+	}
+	w.Flush()
+	fmt.Printf("Draws: %d\n", result.Draws)
+	fmt.Printf("Average turns: %.1f\n", result.AverageTurns())
+	fmt.Println()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " TEAM\tPOKEMON\tKOS/BATTLE\tFAINTED")
+	for i, team := range teams {
+		for j, c := range team.Members {
+			stats := result.Members[i][j]
+			fmt.Fprintf(w, " %s\t%s Lv. %d\t%.2f\t%.1f%%\n", team.Name, c.Name, c.Level, float64(stats.KOs)/float64(result.Runs), 100*float64(stats.Fainted)/float64(result.Runs))
+		}
+	}
+	w.Flush()
+}
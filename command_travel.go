@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+func commandTravel(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You can't leave in the middle of a battle! Use run to get away.")
+		return errBattleInProgress
+	}
+	if len(cfg.args) == 0 {
+		fmt.Printf("You are in %s.\n", cfg.location)
+		fmt.Println("From here you can travel to:")
+		for _, name := range cfg.world.Neighbours(cfg.location) {
+			fmt.Printf(" - %s\n", name)
+		}
+		return nil
+	}
+	if len(cfg.args) != 1 {
+		fmt.Println("You must specify one area to travel to!")
+		return errors.New("too many arguments")
+	}
+
+	destination := cfg.args[0]
+	if destination == cfg.location {
+		fmt.Printf("You are already in %s.\n", destination)
+		return nil
+	}
+	area, exists := cfg.world.Area(destination)
+	if !exists {
+		fmt.Printf("%s is not on the map.\n", destination)
+		return errors.New("unknown area")
+	}
+	if !cfg.world.Connected(cfg.location, destination) {
+		fmt.Printf("You can't get to %s from here. Use travel to see where you can go.\n", destination)
+		return errors.New("area not connected")
+	}
+	if area.RequiresBadge != "" && !contains(cfg.badges, area.RequiresBadge) {
+		fmt.Printf("A guard blocks the way to %s. You need the %s to pass.\n", destination, area.RequiresBadge)
+		return errors.New("missing badge")
+	}
+
+	cfg.location = destination
+	cfg.wild = nil
+	fmt.Printf("You travelled to %s.\n", destination)
+	return nil
+}
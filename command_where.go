@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+func commandWhere(cfg *config) error {
+	args, flags := parseArgs(cfg.args)
+	if len(args) != 1 {
+		fmt.Println("You must specify a pokemon!")
+		return errors.New("missing argument")
+	}
+	pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+args[0], cfg.cache)
+	if err != nil {
+		return err
+	}
+	encounters, err := cfg.client.GetPokemonEncounters(pokemon, cfg.cache)
+	if err != nil {
+		return err
+	}
+	if version, ok := flags["version"]; ok {
+		encounters = filterVersion(encounters, version)
+	}
+	if len(encounters) == 0 {
+		fmt.Printf("%s can not be found in the wild.\n", pokemon.Name)
+		return nil
+	}
+
+	sort.SliceStable(encounters, func(i, j int) bool {
+		return encounters[i].Chance > encounters[j].Chance
+	})
+
+	fmt.Printf("%s can be found in:\n", pokemon.Name)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " AREA\tVERSION\tMETHOD\tLEVELS\tCHANCE")
+	for _, e := range encounters {
+		fmt.Fprintf(w, " %s\t%s\t%s\t%s\t%d%%\n", e.Area, e.Version, e.Method, levelRange(e.MinLevel, e.MaxLevel), e.Chance)
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+// evolution is the outcome of trying to evolve a pokemon.
+type evolution int
+
+const (
+	// evolutionNone means the trigger doesn't make the species evolve.
+	evolutionNone evolution = iota
+	evolutionDone
+	// evolutionStopped means the player stopped the evolution.
+	evolutionStopped
+)
+
+// tryEvolve evolves a caught pokemon if the trigger makes its species
+// evolve and the player doesn't stop it.
+func tryEvolve(cfg *config, c *pokedex.Caught, trigger pokedex.Trigger) (evolution, error) {
+	pokemon := cfg.pokedex.Species[c.Species]
+	species, err := cfg.client.GetPokemonSpecies(pokemon.Species.URL, cfg.cache)
+	if err != nil {
+		return evolutionNone, err
+	}
+	chain, err := cfg.client.GetEvolutionChain(species.EvolutionChain.URL, cfg.cache)
+	if err != nil {
+		return evolutionNone, err
+	}
+	target, ok := chain.Evolution(species.Name, trigger)
+	if !ok {
+		return evolutionNone, nil
+	}
+
+	fmt.Printf("What? %s is evolving! (press enter to continue, or type b to stop it) ", c.Name())
+	if cfg.scanner.Scan() && strings.TrimSpace(strings.ToLower(cfg.scanner.Text())) == "b" {
+		fmt.Printf("Huh? %s stopped evolving!\n", c.Name())
+		return evolutionStopped, nil
+	}
+
+	evolved, err := evolvedPokemon(cfg, target, pokedex.FormSuffix(c.Form, pokemon.Species.Name))
+	if err != nil {
+		return evolutionNone, err
+	}
+	name := c.Name()
+	if err := cfg.pokedex.Evolve(c.ID, evolved); err != nil {
+		return evolutionNone, err
+	}
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", name, evolved.Name)
+	return evolutionDone, nil
+}
+
+// evolvedPokemon fetches the species a pokemon evolves into. A pokemon in a
+// regional form evolves into the same regional variety, like rattata-alola
+// into raticate-alola, if there is one.
+func evolvedPokemon(cfg *config, target, formSuffix string) (pokedex.Pokemon, error) {
+	if formSuffix != "" {
+		evolved, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+target+"-"+formSuffix, cfg.cache)
+		if !errors.Is(err, api.ErrNotFound) {
+			return evolved, err
+		}
+	}
+	return cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+target, cfg.cache)
+}
+
+// evolveLeveledUp gives every pokemon that leveled up in the last battle
+// the chance to evolve now that it's over.
+func evolveLeveledUp(cfg *config) {
+	leveledUp := cfg.leveledUp
+	cfg.leveledUp = nil
+	for _, id := range leveledUp {
+		c, exists := cfg.pokedex.Get(id)
+		if !exists {
+			continue
+		}
+		if _, err := tryEvolve(cfg, c, pokedex.Trigger{Name: "level-up", Level: c.Level}); err != nil {
+			fmt.Println("Could not check evolution:", err)
+		}
+	}
+}
+
+func commandTrade(cfg *config) error {
+	if cfg.battle != nil {
+		fmt.Println("You can't trade in the middle of a battle!")
+		return errBattleInProgress
+	}
+	if len(cfg.args) != 1 {
+		fmt.Println("You must specify the ID of the pokemon to trade!")
+		return errors.New("missing argument")
+	}
+	c, err := caughtByID(cfg, cfg.args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("You traded %s to a friend, and they traded it right back.\n", c.Name())
+	_, err = tryEvolve(cfg, c, pokedex.Trigger{Name: "trade"})
+	return err
+}
+
+func useEvolutionItem(cfg *config, item string, c *pokedex.Caught) error {
+	result, err := tryEvolve(cfg, c, pokedex.Trigger{Name: "use-item", Item: item})
+	if err != nil {
+		return err
+	}
+	switch result {
+	case evolutionNone:
+		fmt.Println("It won't have any effect.")
+		return nil
+	case evolutionStopped:
+		return nil
+	}
+	return cfg.inventory.Remove(item, 1)
+}
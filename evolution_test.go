@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/pokecache"
+)
+
+// serverTransport sends every request to a test server instead of PokeAPI.
+type serverTransport struct {
+	server *url.URL
+}
+
+func (t serverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.server.Scheme
+	req.URL.Host = t.server.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestEvolvedPokemonFallsBackToTheSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/pokemon/gastrodon":
+			w.Write([]byte(`{"name": "gastrodon", "species": {"name": "gastrodon"}}`))
+		case "/api/v2/pokemon/raticate-alola":
+			w.Write([]byte(`{"name": "raticate-alola", "species": {"name": "raticate"}}`))
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := &config{
+		cache:  pokecache.NewCache(5 * time.Second),
+		client: api.NewClientWithTransport(5*time.Second, serverTransport{server: serverURL}),
+	}
+
+	cases := []struct {
+		target   string
+		suffix   string
+		expected string
+	}{
+		{target: "gastrodon", suffix: "east", expected: "gastrodon"},
+		{target: "raticate", suffix: "alola", expected: "raticate-alola"},
+		{target: "gastrodon", suffix: "", expected: "gastrodon"},
+	}
+	for _, c := range cases {
+		evolved, err := evolvedPokemon(cfg, c.target, c.suffix)
+		if err != nil {
+			t.Fatalf("%s-%s: unexpected error: %v", c.target, c.suffix, err)
+		}
+		if evolved.Name != c.expected {
+			t.Errorf("%s-%s: expected %s, got %s", c.target, c.suffix, c.expected, evolved.Name)
+		}
+	}
+
+	if _, err := evolvedPokemon(cfg, "missingno", ""); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown pokemon, got %v", err)
+	}
+	if _, exists := cfg.cache.Get("https://pokeapi.co/api/v2/pokemon/gastrodon-east"); exists {
+		t.Errorf("expected the 404 to not be cached")
+	}
+}
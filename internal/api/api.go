@@ -1,16 +1,25 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/png"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/samersawan/pokedexcli/internal/battle"
 	"github.com/samersawan/pokedexcli/internal/pokecache"
 	"github.com/samersawan/pokedexcli/internal/pokedex"
 )
 
+var ErrNotFound = errors.New("resource not found")
+
 type locationResponse struct {
 	Count    int     `json:"count"`
 	Next     string  `json:"next"`
@@ -22,31 +31,192 @@ type locationResponse struct {
 }
 
 type pokemonListResponse struct {
+	Name              string `json:"name"`
 	PokemonEncounters []struct {
 		Pokemon struct {
 			Name string `json:"name"`
 			URL  string `json:"url"`
 		} `json:"pokemon"`
-		VersionDetails []struct {
-			EncounterDetails []struct {
-				Chance          int   `json:"chance"`
-				ConditionValues []any `json:"condition_values"`
-				MaxLevel        int   `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
-			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
+		VersionDetails []versionDetails `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
 
+type versionDetails struct {
+	EncounterDetails []struct {
+		Chance          int   `json:"chance"`
+		ConditionValues []any `json:"condition_values"`
+		MaxLevel        int   `json:"max_level"`
+		Method          struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"method"`
+		MinLevel int `json:"min_level"`
+	} `json:"encounter_details"`
+	MaxChance int `json:"max_chance"`
+	Version   struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"version"`
+}
+
+// Encounter is one way of finding a Pokemon in a location area: a single
+// game version and encounter method, with the level range and the summed
+// chance of every encounter slot that uses that method.
+type Encounter struct {
+	Pokemon  string
+	Area     string
+	Version  string
+	Method   string
+	MinLevel int
+	MaxLevel int
+	Chance   int
+}
+
+func summarizeEncounters(pokemon, area string, details []versionDetails) []Encounter {
+	encounters := []Encounter{}
+	for _, vd := range details {
+		index := map[string]int{}
+		for _, d := range vd.EncounterDetails {
+			i, exists := index[d.Method.Name]
+			if !exists {
+				index[d.Method.Name] = len(encounters)
+				encounters = append(encounters, Encounter{
+					Pokemon:  pokemon,
+					Area:     area,
+					Version:  vd.Version.Name,
+					Method:   d.Method.Name,
+					MinLevel: d.MinLevel,
+					MaxLevel: d.MaxLevel,
+					Chance:   d.Chance,
+				})
+				continue
+			}
+			e := &encounters[i]
+			e.MinLevel = min(e.MinLevel, d.MinLevel)
+			e.MaxLevel = max(e.MaxLevel, d.MaxLevel)
+			e.Chance += d.Chance
+		}
+	}
+	return encounters
+}
+
+type pokemonEncountersResponse []struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+	VersionDetails []versionDetails `json:"version_details"`
+}
+
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type namedResourceList struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []namedResource `json:"results"`
+}
+
+type regionResponse struct {
+	Name           string          `json:"name"`
+	Locations      []namedResource `json:"locations"`
+	MainGeneration namedResource   `json:"main_generation"`
+	Pokedexes      []namedResource `json:"pokedexes"`
+}
+
+type locationDetailResponse struct {
+	Name   string          `json:"name"`
+	Region namedResource   `json:"region"`
+	Areas  []namedResource `json:"areas"`
+}
+
+type versionGroupResponse struct {
+	Name     string          `json:"name"`
+	Versions []namedResource `json:"versions"`
+}
+
+type generationResponse struct {
+	Name           string          `json:"name"`
+	MainRegion     namedResource   `json:"main_region"`
+	PokemonSpecies []namedResource `json:"pokemon_species"`
+}
+
+type pokedexResponse struct {
+	Name           string        `json:"name"`
+	Region         namedResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int           `json:"entry_number"`
+		PokemonSpecies namedResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+type natureResponse struct {
+	Name          string         `json:"name"`
+	IncreasedStat *namedResource `json:"increased_stat"`
+	DecreasedStat *namedResource `json:"decreased_stat"`
+}
+
+type moveResponse struct {
+	Name        string        `json:"name"`
+	Type        namedResource `json:"type"`
+	Power       *int          `json:"power"`
+	Accuracy    *int          `json:"accuracy"`
+	PP          *int          `json:"pp"`
+	Priority    int           `json:"priority"`
+	DamageClass namedResource `json:"damage_class"`
+	Meta        *struct {
+		Ailment       namedResource `json:"ailment"`
+		AilmentChance int           `json:"ailment_chance"`
+	} `json:"meta"`
+}
+
+type Generation struct {
+	Name    string
+	Region  string
+	Species []string
+}
+
+// RegionalDex is a Pokedex as listed by PokeAPI's /pokedex endpoint, with
+// species in entry number order.
+type RegionalDex struct {
+	Name    string
+	Region  string
+	Species []string
+}
+
+type Region struct {
+	Name           string
+	MainGeneration string
+	Locations      []string
+	Pokedexes      []string
+}
+
+type Location struct {
+	Name   string
+	Region string
+	Areas  []string
+}
+
+type itemResponse struct {
+	Name          string        `json:"name"`
+	Cost          int           `json:"cost"`
+	Category      namedResource `json:"category"`
+	EffectEntries []struct {
+		ShortEffect string        `json:"short_effect"`
+		Language    namedResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+type Item struct {
+	Name     string
+	Cost     int
+	Category string
+	Effect   string
+}
+
 type Client struct {
 	httpClient http.Client
 }
@@ -59,6 +229,54 @@ func NewClient(timeout time.Duration) Client {
 	}
 }
 
+// NewClientWithTransport returns a client that sends its requests through
+// transport, like one that points PokeAPI URLs at a test server.
+func NewClientWithTransport(timeout time.Duration, transport http.RoundTripper) Client {
+	client := NewClient(timeout)
+	client.httpClient.Transport = transport
+	return client
+}
+
+func (client *Client) get(url string, c *pokecache.Cache) ([]byte, error) {
+	entry, err := client.fetch(url, pokecache.API, c)
+	return entry.Val, err
+}
+
+// fetch returns the response to a GET request from a cache namespace,
+// downloading and caching it on a miss.
+func (client *Client) fetch(url, namespace string, c *pokecache.Cache) (pokecache.Entry, error) {
+	if entry, exists := c.GetEntry(namespace, url); exists {
+		return entry, nil
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return pokecache.Entry{}, err
+	}
+
+	res, err := client.httpClient.Do(req)
+	if err != nil {
+		return pokecache.Entry{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return pokecache.Entry{}, fmt.Errorf("%w: %s", ErrNotFound, url)
+	}
+	if res.StatusCode > 299 {
+		return pokecache.Entry{}, fmt.Errorf("%s: %s", url, res.Status)
+	}
+
+	dat, err := io.ReadAll(res.Body)
+	if err != nil {
+		return pokecache.Entry{}, err
+	}
+
+	entry := pokecache.Entry{Val: dat, ContentType: res.Header.Get("Content-Type")}
+	c.AddEntry(namespace, url, entry)
+	return entry, nil
+}
+
 func (client *Client) GetLocations(url string, c *pokecache.Cache) (*string, string, []string, error) {
 	locations := locationResponse{}
 	if dat, exists := c.Get(url); exists {
@@ -104,77 +322,345 @@ func (client *Client) GetLocations(url string, c *pokecache.Cache) (*string, str
 
 }
 
-func (client *Client) ExploreLocation(url string, c *pokecache.Cache) ([]string, error) {
-	pokemon := pokemonListResponse{}
-	if dat, exists := c.Get(url); exists {
-		err := json.Unmarshal(dat, &pokemon)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
+func (client *Client) ExploreLocation(url string, c *pokecache.Cache) ([]Encounter, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return nil, err
+	}
 
-		res, err := client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer res.Body.Close()
+	area := pokemonListResponse{}
+	err = json.Unmarshal(dat, &area)
+	if err != nil {
+		return nil, err
+	}
 
-		dat, err := io.ReadAll(res.Body)
-		if err != nil {
-			return nil, err
-		}
+	encounters := []Encounter{}
+	for _, e := range area.PokemonEncounters {
+		encounters = append(encounters, summarizeEncounters(e.Pokemon.Name, area.Name, e.VersionDetails)...)
+	}
+	return encounters, nil
+}
 
-		c.Add(url, dat)
+func (client *Client) GetPokemonInfo(url string, c *pokecache.Cache) (pokedex.Pokemon, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.Pokemon{}, err
+	}
 
-		err = json.Unmarshal(dat, &pokemon)
-		if err != nil {
-			return nil, err
-		}
+	pokemon := pokedex.Pokemon{}
+	err = json.Unmarshal(dat, &pokemon)
+	if err != nil {
+		return pokedex.Pokemon{}, err
+	}
+	return pokemon, nil
+}
+
+func (client *Client) GetPokemonEncounters(pokemon pokedex.Pokemon, c *pokecache.Cache) ([]Encounter, error) {
+	dat, err := client.get(pokemon.LocationAreaEncounters, c)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := pokemonEncountersResponse{}
+	err = json.Unmarshal(dat, &areas)
+	if err != nil {
+		return nil, err
 	}
 
-	pokemonNames := make([]string, len(pokemon.PokemonEncounters))
-	for i := 0; i < len(pokemonNames); i++ {
-		pokemonNames[i] = pokemon.PokemonEncounters[i].Pokemon.Name
+	encounters := []Encounter{}
+	for _, a := range areas {
+		encounters = append(encounters, summarizeEncounters(pokemon.Name, a.LocationArea.Name, a.VersionDetails)...)
 	}
-	return pokemonNames, nil
+	return encounters, nil
 }
 
-func (client *Client) GetPokemonInfo(url string, c *pokecache.Cache) (pokedex.Pokemon, error) {
-	pokemon := pokedex.Pokemon{}
+func (client *Client) GetRegions(url string, c *pokecache.Cache) ([]string, error) {
+	return client.getNames(url, c)
+}
 
-	if dat, exists := c.Get(url); exists {
-		err := json.Unmarshal(dat, &pokemon)
-		if err != nil {
-			return pokedex.Pokemon{}, err
-		}
-	} else {
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return pokedex.Pokemon{}, err
-		}
+func (client *Client) GetGenerations(url string, c *pokecache.Cache) ([]string, error) {
+	return client.getNames(url, c)
+}
 
-		res, err := client.httpClient.Do(req)
-		if err != nil {
-			return pokedex.Pokemon{}, err
-		}
-		defer res.Body.Close()
+func (client *Client) GetNatures(url string, c *pokecache.Cache) ([]string, error) {
+	return client.getNames(url, c)
+}
 
-		dat, err := io.ReadAll(res.Body)
-		if err != nil {
-			return pokedex.Pokemon{}, err
-		}
+func (client *Client) GetPokemonNames(url string, c *pokecache.Cache) ([]string, error) {
+	return client.getNames(url, c)
+}
 
-		c.Add(url, dat)
+func (client *Client) GetNature(url string, c *pokecache.Cache) (pokedex.Nature, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.Nature{}, err
+	}
 
-		err = json.Unmarshal(dat, &pokemon)
-		if err != nil {
-			return pokedex.Pokemon{}, err
+	nature := natureResponse{}
+	err = json.Unmarshal(dat, &nature)
+	if err != nil {
+		return pokedex.Nature{}, err
+	}
+
+	n := pokedex.Nature{Name: nature.Name}
+	if nature.IncreasedStat != nil {
+		n.Increased = nature.IncreasedStat.Name
+	}
+	if nature.DecreasedStat != nil {
+		n.Decreased = nature.DecreasedStat.Name
+	}
+	return n, nil
+}
+
+func (client *Client) getNames(url string, c *pokecache.Cache) ([]string, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return nil, err
+	}
+
+	list := namedResourceList{}
+	err = json.Unmarshal(dat, &list)
+	if err != nil {
+		return nil, err
+	}
+	return resourceNames(list.Results), nil
+}
+
+// GetVersionGroupVersions returns the game versions of a version group, like
+// red and blue for red-blue.
+func (client *Client) GetVersionGroupVersions(url string, c *pokecache.Cache) ([]string, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return nil, err
+	}
+
+	group := versionGroupResponse{}
+	err = json.Unmarshal(dat, &group)
+	if err != nil {
+		return nil, err
+	}
+	return resourceNames(group.Versions), nil
+}
+
+func (client *Client) GetGeneration(url string, c *pokecache.Cache) (Generation, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return Generation{}, err
+	}
+
+	generation := generationResponse{}
+	err = json.Unmarshal(dat, &generation)
+	if err != nil {
+		return Generation{}, err
+	}
+	return Generation{
+		Name:    generation.Name,
+		Region:  generation.MainRegion.Name,
+		Species: resourceNames(generation.PokemonSpecies),
+	}, nil
+}
+
+func (client *Client) GetPokedex(url string, c *pokecache.Cache) (RegionalDex, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return RegionalDex{}, err
+	}
+
+	dex := pokedexResponse{}
+	err = json.Unmarshal(dat, &dex)
+	if err != nil {
+		return RegionalDex{}, err
+	}
+
+	sort.Slice(dex.PokemonEntries, func(i, j int) bool {
+		return dex.PokemonEntries[i].EntryNumber < dex.PokemonEntries[j].EntryNumber
+	})
+	species := make([]string, len(dex.PokemonEntries))
+	for i, e := range dex.PokemonEntries {
+		species[i] = e.PokemonSpecies.Name
+	}
+	return RegionalDex{
+		Name:    dex.Name,
+		Region:  dex.Region.Name,
+		Species: species,
+	}, nil
+}
+
+func (client *Client) GetRegion(url string, c *pokecache.Cache) (Region, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return Region{}, err
+	}
+
+	region := regionResponse{}
+	err = json.Unmarshal(dat, &region)
+	if err != nil {
+		return Region{}, err
+	}
+	return Region{
+		Name:           region.Name,
+		MainGeneration: region.MainGeneration.Name,
+		Locations:      resourceNames(region.Locations),
+		Pokedexes:      resourceNames(region.Pokedexes),
+	}, nil
+}
+
+func (client *Client) GetLocation(url string, c *pokecache.Cache) (Location, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return Location{}, err
+	}
+
+	location := locationDetailResponse{}
+	err = json.Unmarshal(dat, &location)
+	if err != nil {
+		return Location{}, err
+	}
+	return Location{
+		Name:   location.Name,
+		Region: location.Region.Name,
+		Areas:  resourceNames(location.Areas),
+	}, nil
+}
+
+func resourceNames(resources []namedResource) []string {
+	names := make([]string, len(resources))
+	for i := 0; i < len(resources); i++ {
+		names[i] = resources[i].Name
+	}
+	return names
+}
+
+func (client *Client) GetPokemonSpecies(url string, c *pokecache.Cache) (pokedex.Species, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.Species{}, err
+	}
+
+	species := pokedex.Species{}
+	err = json.Unmarshal(dat, &species)
+	if err != nil {
+		return pokedex.Species{}, err
+	}
+	return species, nil
+}
+
+func (client *Client) GetPokemonForm(url string, c *pokecache.Cache) (pokedex.Form, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.Form{}, err
+	}
+
+	form := pokedex.Form{}
+	err = json.Unmarshal(dat, &form)
+	if err != nil {
+		return pokedex.Form{}, err
+	}
+	return form, nil
+}
+
+// GetImage downloads an image, like a sprite PNG. Images are cached apart
+// from API responses so they can't push them out of the cache.
+func (client *Client) GetImage(url string, c *pokecache.Cache) (image.Image, error) {
+	entry, err := client.fetch(url, pokecache.Images, c)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(entry.ContentType, "image/") {
+		return nil, fmt.Errorf("%s is not an image but %s", url, entry.ContentType)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(entry.Val))
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func (client *Client) GetItem(url string, c *pokecache.Cache) (Item, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item := itemResponse{}
+	err = json.Unmarshal(dat, &item)
+	if err != nil {
+		return Item{}, err
+	}
+
+	effect := ""
+	for _, e := range item.EffectEntries {
+		if e.Language.Name == "en" {
+			effect = e.ShortEffect
 		}
 	}
+	return Item{
+		Name:     item.Name,
+		Cost:     item.Cost,
+		Category: item.Category.Name,
+		Effect:   effect,
+	}, nil
+}
 
-	return pokemon, nil
+func (client *Client) GetMove(url string, c *pokecache.Cache) (battle.Move, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return battle.Move{}, err
+	}
+
+	move := moveResponse{}
+	err = json.Unmarshal(dat, &move)
+	if err != nil {
+		return battle.Move{}, err
+	}
+
+	m := battle.Move{
+		Name:        move.Name,
+		Type:        move.Type.Name,
+		Priority:    move.Priority,
+		DamageClass: move.DamageClass.Name,
+	}
+	if move.Power != nil {
+		m.Power = *move.Power
+	}
+	if move.Accuracy != nil {
+		m.Accuracy = *move.Accuracy
+	}
+	if move.PP != nil {
+		m.PP = *move.PP
+	}
+	if move.Meta != nil && move.Meta.Ailment.Name != "none" {
+		m.Ailment = move.Meta.Ailment.Name
+		m.AilmentChance = move.Meta.AilmentChance
+	}
+	return m, nil
+}
+
+func (client *Client) GetGrowthRate(url string, c *pokecache.Cache) (pokedex.GrowthRate, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.GrowthRate{}, err
+	}
+
+	growthRate := pokedex.GrowthRate{}
+	err = json.Unmarshal(dat, &growthRate)
+	if err != nil {
+		return pokedex.GrowthRate{}, err
+	}
+	return growthRate, nil
+}
+
+func (client *Client) GetEvolutionChain(url string, c *pokecache.Cache) (pokedex.EvolutionChain, error) {
+	dat, err := client.get(url, c)
+	if err != nil {
+		return pokedex.EvolutionChain{}, err
+	}
+
+	chain := pokedex.EvolutionChain{}
+	err = json.Unmarshal(dat, &chain)
+	if err != nil {
+		return pokedex.EvolutionChain{}, err
+	}
+	return chain, nil
 }
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/pokecache"
+)
+
+const areaResponse = `{
+	"name": "kanto-route-1-area",
+	"pokemon_encounters": [{
+		"pokemon": {"name": "pidgey"},
+		"version_details": [{
+			"version": {"name": "red"},
+			"max_chance": 50,
+			"encounter_details": [
+				{"chance": 30, "min_level": 2, "max_level": 3, "method": {"name": "walk"}},
+				{"chance": 15, "min_level": 4, "max_level": 5, "method": {"name": "walk"}},
+				{"chance": 5, "min_level": 10, "max_level": 10, "method": {"name": "surf"}}
+			]
+		}, {
+			"version": {"name": "blue"},
+			"max_chance": 25,
+			"encounter_details": [
+				{"chance": 25, "min_level": 3, "max_level": 4, "method": {"name": "walk"}}
+			]
+		}]
+	}]
+}`
+
+func TestExploreLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(areaResponse))
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	encounters, err := client.ExploreLocation(server.URL, pokecache.NewCache(5*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Encounter{
+		{Pokemon: "pidgey", Area: "kanto-route-1-area", Version: "red", Method: "walk", MinLevel: 2, MaxLevel: 5, Chance: 45},
+		{Pokemon: "pidgey", Area: "kanto-route-1-area", Version: "red", Method: "surf", MinLevel: 10, MaxLevel: 10, Chance: 5},
+		{Pokemon: "pidgey", Area: "kanto-route-1-area", Version: "blue", Method: "walk", MinLevel: 3, MaxLevel: 4, Chance: 25},
+	}
+	if len(encounters) != len(expected) {
+		t.Fatalf("expected %d encounters, got %d", len(expected), len(encounters))
+	}
+	for i := range expected {
+		if encounters[i] != expected[i] {
+			t.Errorf("encounter %d: expected %+v, got %+v", i, expected[i], encounters[i])
+		}
+	}
+}
+
+func TestGetImageIsCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		img.Set(1, 1, color.RGBA{G: 255, A: 255})
+		w.Header().Set("Content-Type", "image/png")
+		png.Encode(w, img)
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	cache := pokecache.NewCache(5 * time.Second)
+	for i := 0; i < 2; i++ {
+		img, err := client.GetImage(server.URL, cache)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, g, _, _ := img.At(1, 1).RGBA(); g == 0 {
+			t.Errorf("expected a green pixel at 1,1")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second download to come from the cache, got %d requests", requests)
+	}
+	if entry, ok := cache.GetEntry(pokecache.Images, server.URL); !ok || entry.ContentType != "image/png" {
+		t.Errorf("expected the image to be cached with its content type, got %+v", entry)
+	}
+	if _, ok := cache.Get(server.URL); ok {
+		t.Errorf("expected the image to not be cached with API responses")
+	}
+}
@@ -0,0 +1,299 @@
+package battle
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type ActionKind int
+
+const (
+	Fight ActionKind = iota
+	Switch
+	// Pass is the action of a side that spent its turn on something outside
+	// the battle engine, like throwing a Poke Ball.
+	Pass
+)
+
+type Action struct {
+	Kind   ActionKind
+	Move   int
+	Switch int
+}
+
+const (
+	Player   = 0
+	Opponent = 1
+)
+
+type Battle struct {
+	Sides [2]*Side
+	Turns int
+
+	rng            *rand.Rand
+	escapeAttempts int
+	faced          map[*Combatant][]*Combatant
+}
+
+func New(player, opponent *Side, r *rand.Rand) *Battle {
+	b := &Battle{
+		Sides: [2]*Side{player, opponent},
+		rng:   r,
+		faced: make(map[*Combatant][]*Combatant),
+	}
+	b.markFaced()
+	return b
+}
+
+// Participants returns the player's pokemon that battled an opponent, who
+// share the experience for defeating it.
+func (b *Battle) Participants(opponent *Combatant) []*Combatant {
+	participants := []*Combatant{}
+	for _, c := range b.faced[opponent] {
+		if !c.Fainted() {
+			participants = append(participants, c)
+		}
+	}
+	return participants
+}
+
+func (b *Battle) markFaced() {
+	player := b.Sides[Player].Current()
+	opponent := b.Sides[Opponent].Current()
+	for _, c := range b.faced[opponent] {
+		if c == player {
+			return
+		}
+	}
+	b.faced[opponent] = append(b.faced[opponent], player)
+}
+
+func (b *Battle) Over() bool {
+	return b.Sides[Player].Defeated() || b.Sides[Opponent].Defeated()
+}
+
+// Winner returns the side that won, or -1 while the battle is still going.
+func (b *Battle) Winner() int {
+	switch {
+	case b.Sides[Opponent].Defeated():
+		return Player
+	case b.Sides[Player].Defeated():
+		return Opponent
+	}
+	return -1
+}
+
+// Step plays out one turn and returns what happened, in order. Switches go
+// first, then moves by priority and speed.
+func (b *Battle) Step(actions [2]Action) []string {
+	log := []string{}
+	b.Turns++
+
+	for i, action := range actions {
+		side := b.Sides[i]
+		if action.Kind != Switch || action.Switch == side.Active {
+			continue
+		}
+		log = append(log, fmt.Sprintf("%s withdrew %s.", side.Name, side.Current().Name))
+		side.Active = action.Switch
+		log = append(log, fmt.Sprintf("%s sent out %s!", side.Name, side.Current().Name))
+	}
+	b.markFaced()
+
+	for _, i := range b.order(actions) {
+		attacker := b.Sides[i].Current()
+		defender := b.Sides[1-i].Current()
+		if attacker.Fainted() || defender.Fainted() {
+			continue
+		}
+		canMove, messages := b.canMove(attacker)
+		log = append(log, messages...)
+		if canMove {
+			log = append(log, b.useMove(attacker, defender, actions[i].Move)...)
+		}
+	}
+
+	for _, side := range b.Sides {
+		log = append(log, residualDamage(side.Current())...)
+	}
+
+	for _, side := range b.Sides {
+		if !side.Current().Fainted() {
+			continue
+		}
+		if next := side.NextAvailable(); next >= 0 {
+			side.Active = next
+			log = append(log, fmt.Sprintf("%s sent out %s!", side.Name, side.Current().Name))
+		}
+	}
+	if !b.Over() {
+		b.markFaced()
+	}
+	return log
+}
+
+// TryEscape uses the generation III/IV escape formula for running from a
+// wild Pokemon. Each failed attempt makes the next one more likely.
+func (b *Battle) TryEscape() bool {
+	b.escapeAttempts++
+	speed := b.Sides[Player].Current().Stats["speed"]
+	opponentSpeed := b.Sides[Opponent].Current().Stats["speed"]
+	if speed >= opponentSpeed || opponentSpeed == 0 {
+		return true
+	}
+	odds := (speed*128/opponentSpeed + 30*b.escapeAttempts) % 256
+	return b.rng.Intn(256) < odds
+}
+
+func (b *Battle) order(actions [2]Action) []int {
+	fighters := []int{}
+	for i, action := range actions {
+		if action.Kind == Fight {
+			fighters = append(fighters, i)
+		}
+	}
+	tiebreak := b.rng.Intn(2)
+	sort.SliceStable(fighters, func(x, y int) bool {
+		i, j := fighters[x], fighters[y]
+		pi, pj := b.priority(i, actions[i]), b.priority(j, actions[j])
+		if pi != pj {
+			return pi > pj
+		}
+		si, sj := b.Sides[i].Current().Speed(), b.Sides[j].Current().Speed()
+		if si != sj {
+			return si > sj
+		}
+		return i == tiebreak
+	})
+	return fighters
+}
+
+func (b *Battle) priority(side int, action Action) int {
+	slot := moveSlot(b.Sides[side].Current(), action.Move)
+	if slot == nil {
+		return 0
+	}
+	return slot.Move.Priority
+}
+
+func moveSlot(c *Combatant, index int) *MoveSlot {
+	if index < 0 || index >= len(c.Moves) || c.Moves[index].PP <= 0 {
+		return nil
+	}
+	return c.Moves[index]
+}
+
+func (b *Battle) useMove(attacker, defender *Combatant, index int) []string {
+	move := Struggle
+	slot := moveSlot(attacker, index)
+	if slot == nil && attacker.HasPP() {
+		for i := range attacker.Moves {
+			if slot = moveSlot(attacker, i); slot != nil {
+				break
+			}
+		}
+	}
+	if slot != nil {
+		move = slot.Move
+		slot.PP--
+	}
+
+	log := []string{fmt.Sprintf("%s used %s!", attacker.Name, move.Name)}
+	if move.Accuracy > 0 && b.rng.Intn(100) >= move.Accuracy {
+		return append(log, fmt.Sprintf("%s's attack missed!", attacker.Name))
+	}
+	if move.Power == 0 {
+		if _, known := inflictedMessages[move.Ailment]; known && !defender.Inflict(move.Ailment, b.rng) {
+			return append(log, "But it failed!")
+		}
+		return append(log, b.inflicted(defender, move)...)
+	}
+
+	damage, effectiveness, critical := b.damage(attacker, defender, move)
+	if effectiveness == 0 {
+		return append(log, fmt.Sprintf("It doesn't affect %s...", defender.Name))
+	}
+	defender.HP = max(defender.HP-damage, 0)
+	if critical {
+		log = append(log, "A critical hit!")
+	}
+	if effectiveness > 1 {
+		log = append(log, "It's super effective!")
+	} else if effectiveness < 1 {
+		log = append(log, "It's not very effective...")
+	}
+	if defender.Fainted() {
+		log = append(log, fmt.Sprintf("%s fainted!", defender.Name))
+	} else if move.AilmentChance > 0 && b.rng.Intn(100) < move.AilmentChance && defender.Inflict(move.Ailment, b.rng) {
+		log = append(log, b.inflicted(defender, move)...)
+	}
+
+	if move.Name == Struggle.Name {
+		attacker.HP = max(attacker.HP-max(attacker.MaxHP()/4, 1), 0)
+		log = append(log, fmt.Sprintf("%s is damaged by recoil!", attacker.Name))
+		if attacker.Fainted() {
+			log = append(log, fmt.Sprintf("%s fainted!", attacker.Name))
+		}
+	}
+	return log
+}
+
+func (b *Battle) inflicted(defender *Combatant, move Move) []string {
+	if format, ok := inflictedMessages[move.Ailment]; ok && defender.Status == move.Ailment {
+		return []string{fmt.Sprintf(format, defender.Name)}
+	}
+	return nil
+}
+
+// damage rolls the damage of a move: the expected damage scaled by a
+// critical hit one time in 24 and a random factor from 85% to 100%.
+func (b *Battle) damage(attacker, defender *Combatant, move Move) (int, float64, bool) {
+	effectiveness := Effectiveness(move.Type, defender.Types)
+	if effectiveness == 0 {
+		return 0, 0, false
+	}
+	critical := b.rng.Intn(24) == 0
+	damage := baseDamage(attacker, defender, move)
+	if critical {
+		damage *= 1.5
+	}
+	damage *= float64(85+b.rng.Intn(16)) / 100
+	return max(int(damage), 1), effectiveness, critical
+}
+
+// baseDamage is the damage formula before the random factor and critical
+// hits, including STAB and type effectiveness.
+func baseDamage(attacker, defender *Combatant, move Move) float64 {
+	attack, defense := attacker.Stats["attack"], defender.Stats["defense"]
+	if move.DamageClass == "special" {
+		attack, defense = attacker.Stats["special-attack"], defender.Stats["special-defense"]
+	} else if attacker.Status == Burn {
+		attack /= 2
+	}
+	defense = max(defense, 1)
+
+	damage := math.Floor(math.Floor(float64((2*attacker.Level/5+2)*move.Power*attack)/float64(defense))/50) + 2
+	for _, t := range attacker.Types {
+		if t == move.Type {
+			damage *= 1.5
+			break
+		}
+	}
+	return damage * Effectiveness(move.Type, defender.Types)
+}
+
+// RandomMove picks one of the combatant's moves that still has PP.
+func RandomMove(c *Combatant, r *rand.Rand) Action {
+	usable := []int{}
+	for i, slot := range c.Moves {
+		if slot.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return Action{Kind: Fight}
+	}
+	return Action{Kind: Fight, Move: usable[r.Intn(len(usable))]}
+}
@@ -0,0 +1,111 @@
+package battle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func testCombatant(name string, types []string, speed int, moves ...Move) *Combatant {
+	c := &Combatant{
+		Name:  name,
+		Level: 50,
+		Types: types,
+		Stats: pokedex.Stats{"hp": 120, "attack": 80, "defense": 80, "special-attack": 80, "special-defense": 80, "speed": speed},
+		HP:    120,
+	}
+	for _, m := range moves {
+		c.Moves = append(c.Moves, &MoveSlot{Move: m, PP: m.PP})
+	}
+	return c
+}
+
+var (
+	tackle      = Move{Name: "tackle", Type: "normal", Power: 40, Accuracy: 100, PP: 35, DamageClass: "physical"}
+	quickAttack = Move{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100, PP: 30, Priority: 1, DamageClass: "physical"}
+	thunderbolt = Move{Name: "thunderbolt", Type: "electric", Power: 90, Accuracy: 100, PP: 15, DamageClass: "special"}
+)
+
+func TestEffectiveness(t *testing.T) {
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{attacking: "electric", defending: []string{"water", "flying"}, expected: 4},
+		{attacking: "electric", defending: []string{"ground"}, expected: 0},
+		{attacking: "fire", defending: []string{"water"}, expected: 0.5},
+		{attacking: "normal", defending: []string{"normal"}, expected: 1},
+		{attacking: "", defending: []string{"ghost"}, expected: 1},
+	}
+	for _, c := range cases {
+		if got := Effectiveness(c.attacking, c.defending); got != c.expected {
+			t.Errorf("%s vs %v: expected %v, got %v", c.attacking, c.defending, c.expected, got)
+		}
+	}
+}
+
+func TestBaseDamage(t *testing.T) {
+	pikachu := testCombatant("pikachu", []string{"electric"}, 90, thunderbolt)
+	gyarados := testCombatant("gyarados", []string{"water", "flying"}, 81, tackle)
+	rattata := testCombatant("rattata", []string{"normal"}, 72, tackle)
+
+	neutral := baseDamage(pikachu, rattata, thunderbolt)
+	super := baseDamage(pikachu, gyarados, thunderbolt)
+	if super != 4*neutral {
+		t.Errorf("expected 4x damage against gyarados, got %v and %v", super, neutral)
+	}
+	stab := baseDamage(rattata, pikachu, tackle)
+	noStab := baseDamage(gyarados, pikachu, tackle)
+	if stab <= noStab {
+		t.Errorf("expected STAB to raise damage, got %v and %v", stab, noStab)
+	}
+}
+
+func TestTurnOrder(t *testing.T) {
+	fast := testCombatant("fast", []string{"normal"}, 100, tackle)
+	slow := testCombatant("slow", []string{"normal"}, 10, tackle, quickAttack)
+	b := New(NewSide("You", []*Combatant{fast}), NewSide("Wild", []*Combatant{slow}), rand.New(rand.NewSource(1)))
+
+	order := b.order([2]Action{{Kind: Fight, Move: 0}, {Kind: Fight, Move: 0}})
+	if order[0] != Player {
+		t.Errorf("expected the faster pokemon to move first")
+	}
+	order = b.order([2]Action{{Kind: Fight, Move: 0}, {Kind: Fight, Move: 1}})
+	if order[0] != Opponent {
+		t.Errorf("expected the priority move to go first")
+	}
+}
+
+func TestBattleEndsWhenTeamFaints(t *testing.T) {
+	strong := testCombatant("strong", []string{"electric"}, 100, thunderbolt)
+	strong.Stats["special-attack"] = 400
+	weak := testCombatant("weak", []string{"water"}, 10, tackle)
+	b := New(NewSide("You", []*Combatant{strong}), NewSide("Wild", []*Combatant{weak}), rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 10 && !b.Over(); i++ {
+		b.Step([2]Action{{Kind: Fight}, RandomMove(weak, b.rng)})
+	}
+	if b.Winner() != Player {
+		t.Errorf("expected the player to win, got %d", b.Winner())
+	}
+	if strong.Moves[0].PP >= thunderbolt.PP {
+		t.Errorf("expected thunderbolt to use PP")
+	}
+}
+
+func TestStruggleWithoutPP(t *testing.T) {
+	a := testCombatant("a", []string{"normal"}, 100, tackle)
+	a.Moves[0].PP = 0
+	d := testCombatant("d", []string{"normal"}, 10, tackle)
+	b := New(NewSide("You", []*Combatant{a}), NewSide("Wild", []*Combatant{d}), rand.New(rand.NewSource(1)))
+
+	b.Step([2]Action{{Kind: Fight}, {Kind: Pass}})
+	if a.HP >= a.MaxHP() {
+		t.Errorf("expected struggle recoil to hurt the user")
+	}
+	if d.HP >= d.MaxHP() {
+		t.Errorf("expected struggle to do damage")
+	}
+}
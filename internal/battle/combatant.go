@@ -0,0 +1,123 @@
+package battle
+
+import (
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+type Move struct {
+	Name        string
+	Type        string
+	Power       int
+	Accuracy    int
+	PP          int
+	Priority    int
+	DamageClass string
+	// Ailment is the status condition the move can cause, and AilmentChance
+	// the percent chance a damaging move causes it. Status moves always do.
+	Ailment       string
+	AilmentChance int
+}
+
+// Struggle is used by a Pokemon that has run out of PP for every move.
+var Struggle = Move{Name: "struggle", Power: 50, DamageClass: "physical"}
+
+type MoveSlot struct {
+	Move Move
+	PP   int
+}
+
+// Combatant is a Pokemon as it takes part in a battle. ID is the caught
+// instance it was built from, or 0 for wild and trainer Pokemon.
+type Combatant struct {
+	ID      int
+	Name    string
+	Species string
+	Level   int
+	Types   []string
+	Stats   pokedex.Stats
+	HP      int
+	Moves   []*MoveSlot
+
+	Status     string
+	SleepTurns int
+}
+
+func (c *Combatant) MaxHP() int {
+	return c.Stats["hp"]
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+func (c *Combatant) HasPP() bool {
+	for _, slot := range c.Moves {
+		if slot.PP > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveIndex returns the index of the named move, or -1 if the combatant
+// doesn't know it.
+func (c *Combatant) MoveIndex(name string) int {
+	for i, slot := range c.Moves {
+		if slot.Move.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+type Side struct {
+	Name   string
+	Team   []*Combatant
+	Active int
+}
+
+func NewSide(name string, team []*Combatant) *Side {
+	s := &Side{Name: name, Team: team}
+	if s.Current().Fainted() {
+		if next := s.NextAvailable(); next >= 0 {
+			s.Active = next
+		}
+	}
+	return s
+}
+
+func (s *Side) Current() *Combatant {
+	return s.Team[s.Active]
+}
+
+// NextAvailable returns the index of the first team member other than the
+// active one that can still fight, or -1 if there is none.
+func (s *Side) NextAvailable() int {
+	for i, c := range s.Team {
+		if i != s.Active && !c.Fainted() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Side) Defeated() bool {
+	for _, c := range s.Team {
+		if !c.Fainted() {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of the combatant that can battle without changing the
+// original's HP, PP or status.
+func (c *Combatant) Clone() *Combatant {
+	clone := *c
+	clone.Moves = make([]*MoveSlot, len(c.Moves))
+	for i, slot := range c.Moves {
+		copied := *slot
+		clone.Moves[i] = &copied
+	}
+	return &clone
+}
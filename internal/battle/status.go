@@ -0,0 +1,107 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Status conditions, named as PokeAPI names the ailments moves cause.
+const (
+	Sleep     = "sleep"
+	Poison    = "poison"
+	Burn      = "burn"
+	Paralysis = "paralysis"
+	Freeze    = "freeze"
+)
+
+var statusImmunities = map[string][]string{
+	Poison:    {"poison", "steel"},
+	Burn:      {"fire"},
+	Paralysis: {"electric"},
+	Freeze:    {"ice"},
+}
+
+var inflictedMessages = map[string]string{
+	Sleep:     "%s fell asleep!",
+	Poison:    "%s was poisoned!",
+	Burn:      "%s was burned!",
+	Paralysis: "%s is paralyzed! It may be unable to move!",
+	Freeze:    "%s was frozen solid!",
+}
+
+// Inflict gives the combatant a status condition unless it already has one
+// or its type is immune. It reports whether the status took hold.
+func (c *Combatant) Inflict(status string, r *rand.Rand) bool {
+	if c.Status != "" || c.Fainted() {
+		return false
+	}
+	if _, known := inflictedMessages[status]; !known {
+		return false
+	}
+	for _, immune := range statusImmunities[status] {
+		for _, t := range c.Types {
+			if t == immune {
+				return false
+			}
+		}
+	}
+	c.Status = status
+	if status == Sleep {
+		c.SleepTurns = 1 + r.Intn(3)
+	}
+	return true
+}
+
+func (c *Combatant) Speed() int {
+	if c.Status == Paralysis {
+		return c.Stats["speed"] / 2
+	}
+	return c.Stats["speed"]
+}
+
+// canMove checks the statuses that can stop a Pokemon from acting this
+// turn, counting down sleep and rolling to thaw out.
+func (b *Battle) canMove(c *Combatant) (bool, []string) {
+	switch c.Status {
+	case Sleep:
+		if c.SleepTurns > 0 {
+			c.SleepTurns--
+			return false, []string{fmt.Sprintf("%s is fast asleep.", c.Name)}
+		}
+		c.Status = ""
+		return true, []string{fmt.Sprintf("%s woke up!", c.Name)}
+	case Freeze:
+		if b.rng.Intn(5) == 0 {
+			c.Status = ""
+			return true, []string{fmt.Sprintf("%s thawed out!", c.Name)}
+		}
+		return false, []string{fmt.Sprintf("%s is frozen solid!", c.Name)}
+	case Paralysis:
+		if b.rng.Intn(4) == 0 {
+			return false, []string{fmt.Sprintf("%s is paralyzed! It can't move!", c.Name)}
+		}
+	}
+	return true, nil
+}
+
+// residualDamage hurts poisoned and burned Pokemon at the end of a turn.
+func residualDamage(c *Combatant) []string {
+	if c.Fainted() {
+		return nil
+	}
+	log := []string{}
+	switch c.Status {
+	case Poison:
+		c.HP = max(c.HP-max(c.MaxHP()/8, 1), 0)
+		log = append(log, fmt.Sprintf("%s is hurt by poison!", c.Name))
+	case Burn:
+		c.HP = max(c.HP-max(c.MaxHP()/16, 1), 0)
+		log = append(log, fmt.Sprintf("%s is hurt by its burn!", c.Name))
+	default:
+		return nil
+	}
+	if c.Fainted() {
+		log = append(log, fmt.Sprintf("%s fainted!", c.Name))
+	}
+	return log
+}
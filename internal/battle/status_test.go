@@ -0,0 +1,58 @@
+package battle
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestInflictImmunities(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	cases := []struct {
+		types    []string
+		status   string
+		expected bool
+	}{
+		{types: []string{"fire"}, status: Burn, expected: false},
+		{types: []string{"grass", "poison"}, status: Poison, expected: false},
+		{types: []string{"electric"}, status: Paralysis, expected: false},
+		{types: []string{"water"}, status: Paralysis, expected: true},
+		{types: []string{"normal"}, status: "confusion", expected: false},
+	}
+	for _, c := range cases {
+		combatant := testCombatant("test", c.types, 50)
+		if got := combatant.Inflict(c.status, r); got != c.expected {
+			t.Errorf("%s on %v: expected %v, got %v", c.status, c.types, c.expected, got)
+		}
+	}
+
+	asleep := testCombatant("test", []string{"normal"}, 50)
+	asleep.Inflict(Sleep, r)
+	if asleep.Inflict(Burn, r) {
+		t.Errorf("expected a pokemon with a status to not get another")
+	}
+}
+
+func TestStatusEffects(t *testing.T) {
+	paralyzed := testCombatant("paralyzed", []string{"normal"}, 100, tackle)
+	paralyzed.Status = Paralysis
+	if paralyzed.Speed() != 50 {
+		t.Errorf("expected paralysis to halve speed, got %d", paralyzed.Speed())
+	}
+
+	poisoned := testCombatant("poisoned", []string{"normal"}, 10, tackle)
+	poisoned.Status = Poison
+	b := New(NewSide("You", []*Combatant{paralyzed}), NewSide("Wild", []*Combatant{poisoned}), rand.New(rand.NewSource(1)))
+	b.Step([2]Action{{Kind: Pass}, {Kind: Pass}})
+	if poisoned.HP != poisoned.MaxHP()-poisoned.MaxHP()/8 {
+		t.Errorf("expected poison to take 1/8 of max HP, got %d/%d", poisoned.HP, poisoned.MaxHP())
+	}
+
+	burned := testCombatant("burned", []string{"normal"}, 10, tackle)
+	healthy := testCombatant("healthy", []string{"normal"}, 10, tackle)
+	target := testCombatant("target", []string{"normal"}, 10, tackle)
+	withoutBurn := baseDamage(healthy, target, tackle)
+	burned.Status = Burn
+	if baseDamage(burned, target, tackle) >= withoutBurn {
+		t.Errorf("expected a burn to weaken physical moves")
+	}
+}
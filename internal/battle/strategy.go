@@ -0,0 +1,140 @@
+package battle
+
+import "math"
+
+// Strategy picks the action of one side of a battle for the next turn.
+// Strategies that need randomness draw from the battle's RNG, so a battle
+// with a seeded RNG always plays out the same way.
+type Strategy interface {
+	Choose(b *Battle, side int) Action
+}
+
+// Strategies are the built-in strategies by name.
+var Strategies = map[string]Strategy{
+	"random":  Random{},
+	"greedy":  Greedy{},
+	"minimax": Minimax{Depth: 2},
+}
+
+// Random uses any move that has PP left.
+type Random struct{}
+
+func (Random) Choose(b *Battle, side int) Action {
+	return RandomMove(b.Sides[side].Current(), b.rng)
+}
+
+// Greedy uses the move that deals the most expected damage this turn, taking
+// accuracy, STAB and type effectiveness into account.
+type Greedy struct{}
+
+func (Greedy) Choose(b *Battle, side int) Action {
+	attacker := b.Sides[side].Current()
+	defender := b.Sides[1-side].Current()
+	best, bestDamage := -1, -1.0
+	for _, i := range usableMoves(attacker) {
+		if damage := expectedDamage(attacker, defender, moveAt(attacker, i)); damage > bestDamage {
+			best, bestDamage = i, damage
+		}
+	}
+	if best < 0 {
+		return Action{Kind: Fight}
+	}
+	return Action{Kind: Fight, Move: best}
+}
+
+// Minimax looks Depth turns ahead, assuming the opponent always answers with
+// the move that is worst for it. Turns are played out with expected damage
+// instead of random rolls.
+type Minimax struct {
+	Depth int
+}
+
+func (m Minimax) Choose(b *Battle, side int) Action {
+	me := b.Sides[side].Current()
+	foe := b.Sides[1-side].Current()
+	moves := usableMoves(me)
+	if moves[0] < 0 {
+		return Action{Kind: Fight}
+	}
+
+	best, bestScore := moves[0], math.Inf(-1)
+	for _, i := range moves {
+		score := math.Inf(1)
+		for _, j := range usableMoves(foe) {
+			score = math.Min(score, m.turn(me, foe, i, j, [2]float64{float64(me.HP), float64(foe.HP)}, max(m.Depth, 1)))
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return Action{Kind: Fight, Move: best}
+}
+
+// turn plays out a turn in which me uses move i and foe uses move j, then
+// looks further ahead. hp holds the HP of me and foe.
+func (m Minimax) turn(me, foe *Combatant, i, j int, hp [2]float64, depth int) float64 {
+	fighters := [2]*Combatant{me, foe}
+	moves := [2]Move{moveAt(me, i), moveAt(foe, j)}
+	first := 1
+	if moves[0].Priority > moves[1].Priority || (moves[0].Priority == moves[1].Priority && me.Speed() > foe.Speed()) {
+		first = 0
+	}
+
+	for _, attacker := range []int{first, 1 - first} {
+		defender := 1 - attacker
+		hp[defender] -= expectedDamage(fighters[attacker], fighters[defender], moves[attacker])
+		if hp[defender] <= 0 {
+			// Winning sooner, or losing later, is better.
+			if defender == 1 {
+				return 2 + float64(depth)
+			}
+			return -2 - float64(depth)
+		}
+	}
+
+	if depth == 1 {
+		return hp[0]/float64(me.MaxHP()) - hp[1]/float64(foe.MaxHP())
+	}
+	best := math.Inf(-1)
+	for _, i := range usableMoves(me) {
+		score := math.Inf(1)
+		for _, j := range usableMoves(foe) {
+			score = math.Min(score, m.turn(me, foe, i, j, hp, depth-1))
+		}
+		best = math.Max(best, score)
+	}
+	return best
+}
+
+// usableMoves returns the indexes of the moves with PP left, or -1 for
+// Struggle when there are none.
+func usableMoves(c *Combatant) []int {
+	usable := []int{}
+	for i, slot := range c.Moves {
+		if slot.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return []int{-1}
+	}
+	return usable
+}
+
+func moveAt(c *Combatant, index int) Move {
+	if index < 0 {
+		return Struggle
+	}
+	return c.Moves[index].Move
+}
+
+func expectedDamage(attacker, defender *Combatant, move Move) float64 {
+	if move.Power == 0 {
+		return 0
+	}
+	damage := baseDamage(attacker, defender, move)
+	if move.Accuracy > 0 {
+		damage *= float64(move.Accuracy) / 100
+	}
+	return damage
+}
@@ -0,0 +1,59 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestGreedy(t *testing.T) {
+	cases := []struct {
+		defender *Combatant
+		expected int
+	}{
+		{defender: testCombatant("gyarados", []string{"water", "flying"}, 81, tackle), expected: 1},
+		{defender: testCombatant("sandslash", []string{"ground"}, 65, tackle), expected: 0},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			pikachu := testCombatant("pikachu", []string{"electric"}, 90, tackle, thunderbolt)
+			b := New(NewSide("You", []*Combatant{c.defender}), NewSide("Wild", []*Combatant{pikachu}), rand.New(rand.NewSource(1)))
+			if action := (Greedy{}).Choose(b, Opponent); action.Move != c.expected {
+				t.Errorf("expected move %d, got %d", c.expected, action.Move)
+			}
+		})
+	}
+}
+
+func TestMinimaxPrefersMovingFirst(t *testing.T) {
+	slow := testCombatant("slow", []string{"electric"}, 10, thunderbolt, quickAttack)
+	fast := testCombatant("fast", []string{"normal"}, 100, tackle)
+	slow.HP, fast.HP = 10, 10
+	b := New(NewSide("You", []*Combatant{fast}), NewSide("Wild", []*Combatant{slow}), rand.New(rand.NewSource(1)))
+
+	if action := (Greedy{}).Choose(b, Opponent); action.Move != 0 {
+		t.Errorf("expected greedy to use the strongest move, got %d", action.Move)
+	}
+	if action := (Minimax{Depth: 2}).Choose(b, Opponent); action.Move != 1 {
+		t.Errorf("expected minimax to use quick-attack to move first, got %d", action.Move)
+	}
+}
+
+func TestStrategiesAreDeterministic(t *testing.T) {
+	for name, strategy := range Strategies {
+		t.Run(name, func(t *testing.T) {
+			logs := [2][]string{}
+			for i := range logs {
+				player := testCombatant("pikachu", []string{"electric"}, 90, tackle, thunderbolt)
+				opponent := testCombatant("rattata", []string{"normal"}, 72, tackle, quickAttack)
+				b := New(NewSide("You", []*Combatant{player}), NewSide("Wild", []*Combatant{opponent}), rand.New(rand.NewSource(42)))
+				for !b.Over() {
+					logs[i] = append(logs[i], b.Step([2]Action{strategy.Choose(b, Player), strategy.Choose(b, Opponent)})...)
+				}
+			}
+			if fmt.Sprint(logs[0]) != fmt.Sprint(logs[1]) {
+				t.Errorf("expected the same battle from the same seed, got\n%v\n%v", logs[0], logs[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+)
+
+type Ball struct {
+	Name     string
+	Modifier float64
+}
+
+var Balls = map[string]Ball{
+	"poke-ball":   {Name: "Poke Ball", Modifier: 1},
+	"great-ball":  {Name: "Great Ball", Modifier: 1.5},
+	"ultra-ball":  {Name: "Ultra Ball", Modifier: 2},
+	"master-ball": {Name: "Master Ball", Modifier: 255},
+}
+
+// StatusModifier returns the catch bonus for a status condition, using the
+// ailment names PokeAPI uses.
+func StatusModifier(status string) float64 {
+	switch status {
+	case "sleep", "freeze":
+		return 2
+	case "paralysis", "poison", "burn":
+		return 1.5
+	}
+	return 1
+}
+
+type Attempt struct {
+	CaptureRate int
+	MaxHP       int
+	HP          int
+	Ball        Ball
+	Status      string
+}
+
+type Result struct {
+	Shakes int
+	Caught bool
+}
+
+// Throw runs the generation III/IV capture algorithm: the modified catch
+// rate a decides the chance b of passing each of four shake checks, and the
+// Pokemon is caught only if all four pass.
+func Throw(attempt Attempt, r *rand.Rand) Result {
+	if attempt.Ball.Modifier >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+
+	a := catchRate(attempt)
+	if a >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+	if a <= 0 {
+		return Result{}
+	}
+
+	b := int(1048560 / math.Sqrt(math.Sqrt(16711680/a)))
+	passed := 0
+	for passed < 4 && r.Intn(65536) < b {
+		passed++
+	}
+	return Result{Shakes: min(passed, 3), Caught: passed == 4}
+}
+
+func catchRate(attempt Attempt) float64 {
+	maxHP := float64(max(attempt.MaxHP, 1))
+	hp := float64(min(max(attempt.HP, 1), attempt.MaxHP))
+	a := math.Floor((3*maxHP - 2*hp) * float64(attempt.CaptureRate) * attempt.Ball.Modifier / (3 * maxHP))
+	return a * StatusModifier(attempt.Status)
+}
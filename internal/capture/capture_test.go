@@ -0,0 +1,46 @@
+package capture
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestThrowGuaranteedCatch(t *testing.T) {
+	cases := []Attempt{
+		{CaptureRate: 3, MaxHP: 100, HP: 100, Ball: Balls["master-ball"]},
+		{CaptureRate: 255, MaxHP: 30, HP: 1, Ball: Balls["ultra-ball"]},
+		{CaptureRate: 190, MaxHP: 30, HP: 1, Ball: Balls["great-ball"], Status: "sleep"},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			res := Throw(c, rand.New(rand.NewSource(1)))
+			if !res.Caught {
+				t.Errorf("expected pokemon to be caught")
+			}
+		})
+	}
+}
+
+func TestThrowIsDeterministic(t *testing.T) {
+	attempt := Attempt{CaptureRate: 45, MaxHP: 40, HP: 40, Ball: Balls["poke-ball"]}
+	for seed := int64(0); seed < 20; seed++ {
+		first := Throw(attempt, rand.New(rand.NewSource(seed)))
+		second := Throw(attempt, rand.New(rand.NewSource(seed)))
+		if first != second {
+			t.Errorf("seed %d: expected %+v, got %+v", seed, first, second)
+		}
+		if first.Shakes < 0 || first.Shakes > 3 {
+			t.Errorf("seed %d: expected 0-3 shakes, got %d", seed, first.Shakes)
+		}
+	}
+}
+
+func TestStatusImprovesOdds(t *testing.T) {
+	attempt := Attempt{CaptureRate: 45, MaxHP: 40, HP: 20, Ball: Balls["poke-ball"]}
+	asleep := attempt
+	asleep.Status = "sleep"
+	if catchRate(asleep) <= catchRate(attempt) {
+		t.Errorf("expected sleep to raise the catch rate")
+	}
+}
@@ -0,0 +1,145 @@
+package chart
+
+import (
+	"math"
+	"strings"
+)
+
+// Bar draws value out of maxValue as a bar width characters wide.
+func Bar(value, maxValue, width int) string {
+	filled := 0
+	if maxValue > 0 {
+		filled = min(max(value*width/maxValue, 0), width)
+	}
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// canvas is a grid of braille characters, each holding 2x4 dots. Dots are
+// about as wide as they are tall, so shapes keep their proportions.
+type canvas struct {
+	width, height int
+	cells         [][]rune
+}
+
+// brailleDots are the bits of the dots of a braille character by column and
+// row.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+func newCanvas(width, height int) *canvas {
+	c := &canvas{width: width, height: height}
+	for y := 0; y < height; y++ {
+		row := make([]rune, width)
+		for x := range row {
+			row[x] = 0x2800
+		}
+		c.cells = append(c.cells, row)
+	}
+	return c
+}
+
+func (c *canvas) set(x, y int) {
+	if x < 0 || y < 0 || x >= 2*c.width || y >= 4*c.height {
+		return
+	}
+	c.cells[y/4][x/2] |= brailleDots[x%2][y%4]
+}
+
+func (c *canvas) line(x0, y0, x1, y1 int) {
+	steps := max(abs(x1-x0), abs(y1-y0), 1)
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		c.set(int(math.Round(float64(x0)+t*float64(x1-x0))), int(math.Round(float64(y0)+t*float64(y1-y0))))
+	}
+}
+
+// text writes a label over the canvas. A label left of the centre ends at x
+// instead of starting there, so it points away from the chart.
+func (c *canvas) text(x, y int, label string, alignRight bool) {
+	if y < 0 || y >= c.height {
+		return
+	}
+	runes := []rune(label)
+	if alignRight {
+		x -= len(runes) - 1
+	}
+	for i, r := range runes {
+		if x+i >= 0 && x+i < c.width {
+			c.cells[y][x+i] = r
+		}
+	}
+}
+
+func (c *canvas) String() string {
+	lines := make([]string, c.height)
+	for y, row := range c.cells {
+		lines[y] = strings.TrimRight(strings.ReplaceAll(string(row), "⠀", " "), " ")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Radar draws values from 0 to 1 as a polygon inside its outline, with the
+// first value at the top and the rest clockwise. Each axis is labelled at its
+// end. radius is in dots.
+func Radar(values []float64, labels []string, radius int) string {
+	const labelWidth = 4
+	// The canvas is split in two halves around the centre, with room for
+	// the labels around the outline.
+	width := (radius+1)/2 + labelWidth + 1
+	height := (radius+3)/4 + 2
+	c := newCanvas(2*width, 2*height)
+	cx, cy := 2*width, 4*height
+
+	point := func(i int, scale float64) (int, int) {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(len(values))
+		x := float64(cx) + scale*float64(radius)*math.Cos(angle)
+		y := float64(cy) + scale*float64(radius)*math.Sin(angle)
+		return int(math.Round(x)), int(math.Round(y))
+	}
+
+	for i := range values {
+		next := (i + 1) % len(values)
+		x0, y0 := point(i, 1)
+		x1, y1 := point(next, 1)
+		c.line(x0, y0, x1, y1)
+		c.line(cx, cy, x0, y0)
+
+		x0, y0 = point(i, min(max(values[i], 0), 1))
+		x1, y1 = point(next, min(max(values[next], 0), 1))
+		c.line(x0, y0, x1, y1)
+	}
+
+	for i, label := range labels {
+		x, y := point(i, 1)
+		col, row := x/2, y/4
+		switch {
+		case x < cx-1:
+			c.text(col-1, row, label, true)
+		case x > cx+1:
+			c.text(col+1, row, label, false)
+		case y < cy:
+			c.text(col-len(label)/2, row-1, label, false)
+		default:
+			c.text(col-len(label)/2, row+1, label, false)
+		}
+	}
+	return c.String()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
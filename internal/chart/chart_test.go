@@ -0,0 +1,51 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBar(t *testing.T) {
+	cases := []struct {
+		value    int
+		expected string
+	}{
+		{value: 0, expected: "░░░░░"},
+		{value: 1, expected: "█░░░░"},
+		{value: 102, expected: "██░░░"},
+		{value: 255, expected: "█████"},
+		{value: 300, expected: "█████"},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if got := Bar(c.value, 255, 5); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestCanvas(t *testing.T) {
+	c := newCanvas(1, 1)
+	c.set(0, 0)
+	c.set(1, 3)
+	c.set(5, 5)
+	if got := c.String(); got != "⢁\n" {
+		t.Errorf("expected the top left and bottom right dots, got %q", got)
+	}
+}
+
+func TestRadar(t *testing.T) {
+	labels := []string{"HP", "Atk", "Def", "Spe", "SpD", "SpA"}
+	out := Radar([]float64{1, 0.5, 0.5, 0.5, 0.5, 0.5}, labels, 12)
+	for _, label := range labels {
+		if !strings.Contains(out, label) {
+			t.Errorf("expected label %s in\n%s", label, out)
+		}
+	}
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if !strings.Contains(lines[0], "HP") {
+		t.Errorf("expected HP at the top, got\n%s", out)
+	}
+}
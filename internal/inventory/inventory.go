@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrNotEnoughItems = errors.New("not enough items")
+	ErrNotEnoughMoney = errors.New("not enough money")
+)
+
+const StartingMoney = 3000
+
+type Inventory struct {
+	Items map[string]int `json:"items"`
+	Money int            `json:"money"`
+}
+
+func New() Inventory {
+	return Inventory{
+		Items: map[string]int{"poke-ball": 5},
+		Money: StartingMoney,
+	}
+}
+
+func (inv *Inventory) Count(item string) int {
+	return inv.Items[item]
+}
+
+func (inv *Inventory) Add(item string, qty int) {
+	if inv.Items == nil {
+		inv.Items = make(map[string]int)
+	}
+	inv.Items[item] += qty
+}
+
+func (inv *Inventory) Remove(item string, qty int) error {
+	if inv.Items[item] < qty {
+		return ErrNotEnoughItems
+	}
+	inv.Items[item] -= qty
+	if inv.Items[item] == 0 {
+		delete(inv.Items, item)
+	}
+	return nil
+}
+
+// Buy pays for qty of an item at the given unit price and adds them to the
+// inventory. Nothing changes if the wallet can't cover the total.
+func (inv *Inventory) Buy(item string, qty, price int) error {
+	// Comparing by division keeps huge quantities from overflowing the total.
+	if price > 0 && qty > inv.Money/price {
+		return ErrNotEnoughMoney
+	}
+	inv.Money -= qty * price
+	inv.Add(item, qty)
+	return nil
+}
+
+func (inv *Inventory) Names() []string {
+	names := make([]string, 0, len(inv.Items))
+	for name := range inv.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
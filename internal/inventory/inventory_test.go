@@ -0,0 +1,79 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddRemove(t *testing.T) {
+	inv := Inventory{}
+	inv.Add("potion", 2)
+	if err := inv.Remove("potion", 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if inv.Count("potion") != 1 {
+		t.Errorf("expected 1 potion, got %d", inv.Count("potion"))
+	}
+	if err := inv.Remove("potion", 2); !errors.Is(err, ErrNotEnoughItems) {
+		t.Errorf("expected ErrNotEnoughItems, got %v", err)
+	}
+	inv.Remove("potion", 1)
+	if len(inv.Names()) != 0 {
+		t.Errorf("expected empty stacks to be removed, got %v", inv.Names())
+	}
+}
+
+func TestBuy(t *testing.T) {
+	inv := Inventory{Money: 1000}
+	if err := inv.Buy("great-ball", 2, 600); !errors.Is(err, ErrNotEnoughMoney) {
+		t.Errorf("expected ErrNotEnoughMoney, got %v", err)
+	}
+	if inv.Money != 1000 || inv.Count("great-ball") != 0 {
+		t.Errorf("expected a failed purchase to change nothing")
+	}
+	if err := inv.Buy("poke-ball", 5, 200); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if inv.Money != 0 || inv.Count("poke-ball") != 5 {
+		t.Errorf("expected 5 poke-balls and no money, got %d and %d", inv.Count("poke-ball"), inv.Money)
+	}
+}
+
+func TestBuyOverflow(t *testing.T) {
+	inv := Inventory{Money: 3000}
+	// 92233720368547758 poke-balls at $200 overflow an int64 total to a
+	// negative price.
+	if err := inv.Buy("poke-ball", 92233720368547758, 200); !errors.Is(err, ErrNotEnoughMoney) {
+		t.Errorf("expected ErrNotEnoughMoney, got %v", err)
+	}
+	if inv.Money != 3000 || inv.Count("poke-ball") != 0 {
+		t.Errorf("expected an overflowing purchase to change nothing, got $%d and %d poke-balls", inv.Money, inv.Count("poke-ball"))
+	}
+}
+
+func TestRemedyApply(t *testing.T) {
+	cases := []struct {
+		item           string
+		hp             int
+		status         string
+		expectedHP     int
+		expectedStatus string
+		expectedEffect bool
+	}{
+		{item: "potion", hp: 10, expectedHP: 30, expectedEffect: true},
+		{item: "potion", hp: 90, expectedHP: 100, expectedEffect: true},
+		{item: "potion", hp: 100, expectedHP: 100, expectedEffect: false},
+		{item: "potion", hp: 0, expectedHP: 0, expectedEffect: false},
+		{item: "antidote", hp: 50, status: "poison", expectedHP: 50, expectedEffect: true},
+		{item: "antidote", hp: 50, status: "burn", expectedHP: 50, expectedStatus: "burn", expectedEffect: false},
+		{item: "full-restore", hp: 1, status: "sleep", expectedHP: 100, expectedEffect: true},
+		{item: "revive", hp: 0, status: "poison", expectedHP: 50, expectedEffect: true},
+		{item: "revive", hp: 10, expectedHP: 10, expectedEffect: false},
+	}
+	for _, c := range cases {
+		hp, status, effect := Medicine[c.item].Apply(c.hp, 100, c.status)
+		if hp != c.expectedHP || status != c.expectedStatus || effect != c.expectedEffect {
+			t.Errorf("%s at %d HP, %q: expected %d, %q, %v, got %d, %q, %v", c.item, c.hp, c.status, c.expectedHP, c.expectedStatus, c.expectedEffect, hp, status, effect)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package inventory
+
+// FullHP is the HP of a remedy that restores all of a Pokemon's HP.
+const FullHP = -1
+
+type Remedy struct {
+	HP     int
+	Cures  []string
+	Revive float64
+}
+
+var allStatuses = []string{"sleep", "poison", "burn", "paralysis", "freeze"}
+
+// Medicine lists the items that heal a Pokemon, keyed by PokeAPI item name.
+var Medicine = map[string]Remedy{
+	"potion":        {HP: 20},
+	"super-potion":  {HP: 50},
+	"hyper-potion":  {HP: 200},
+	"max-potion":    {HP: FullHP},
+	"full-restore":  {HP: FullHP, Cures: allStatuses},
+	"fresh-water":   {HP: 50},
+	"soda-pop":      {HP: 60},
+	"lemonade":      {HP: 80},
+	"moomoo-milk":   {HP: 100},
+	"antidote":      {Cures: []string{"poison"}},
+	"burn-heal":     {Cures: []string{"burn"}},
+	"ice-heal":      {Cures: []string{"freeze"}},
+	"awakening":     {Cures: []string{"sleep"}},
+	"paralyze-heal": {Cures: []string{"paralysis"}},
+	"full-heal":     {Cures: allStatuses},
+	"revive":        {Revive: 0.5},
+	"max-revive":    {Revive: 1},
+}
+
+// Apply returns a Pokemon's HP and status after using the remedy on it, and
+// whether the remedy had any effect.
+func (r Remedy) Apply(hp, maxHP int, status string) (int, string, bool) {
+	if hp <= 0 {
+		if r.Revive == 0 {
+			return hp, status, false
+		}
+		return max(int(float64(maxHP)*r.Revive), 1), "", true
+	}
+
+	effect := false
+	for _, cured := range r.Cures {
+		if cured == status {
+			status = ""
+			effect = true
+		}
+	}
+	if r.HP != 0 && hp < maxHP {
+		if r.HP == FullHP {
+			hp = maxHP
+		} else {
+			hp = min(hp+r.HP, maxHP)
+		}
+		effect = true
+	}
+	return hp, status, effect
+}
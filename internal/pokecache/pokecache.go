@@ -1,44 +1,146 @@
 package pokecache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
+// Namespaces keep different kinds of entries apart, so that each namespace
+// has its own expiry and size budget and only evicts its own entries.
+const (
+	// API holds PokeAPI JSON responses. It is the namespace of Add and Get.
+	API = "api"
+	// Images holds downloaded images like sprites.
+	Images = "images"
+)
+
 type Cache struct {
-	mu      sync.Mutex
+	mu         sync.Mutex
+	namespaces map[string]*namespace
+	interval   time.Duration
+}
+
+type namespace struct {
 	entries map[string]cacheEntry
+	// order holds the keys from oldest to newest.
+	order *list.List
+	size  int
+	// budget is the most bytes the namespace may hold, or 0 for no limit.
+	budget int
+	// ttl is how long entries are kept, or 0 to keep them until evicted.
+	ttl time.Duration
 }
 
 type cacheEntry struct {
 	createdAt time.Time
-	val       []byte
+	element   *list.Element
+	Entry
 }
 
+// Entry is a cached value with the content type it was served with.
+type Entry struct {
+	Val         []byte
+	ContentType string
+}
+
+// NewCache returns a cache whose entries expire after interval unless their
+// namespace is given another TTL.
 func NewCache(interval time.Duration) *Cache {
 	c := &Cache{
-		entries: make(map[string]cacheEntry),
+		namespaces: make(map[string]*namespace),
+		interval:   interval,
 	}
 	go c.reapLoop(interval)
 	return c
 }
 
-func (c *Cache) Add(key string, val []byte) {
+// SetBudget limits the bytes a namespace may hold. Adding past the budget
+// evicts the namespace's oldest entries first.
+func (c *Cache) SetBudget(ns string, budget int) {
 	c.mu.Lock()
-	newEntry := cacheEntry{createdAt: time.Now(), val: val}
-	c.entries[key] = newEntry
-	c.mu.Unlock()
-	return
+	defer c.mu.Unlock()
+	n := c.namespace(ns)
+	n.budget = budget
+	n.evict(0)
+}
+
+// SetTTL sets how long a namespace keeps its entries. A TTL of 0 keeps them
+// until they are evicted to stay within the budget.
+func (c *Cache) SetTTL(ns string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.namespace(ns).ttl = ttl
+}
+
+func (c *Cache) Add(key string, val []byte) {
+	c.AddEntry(API, key, Entry{Val: val, ContentType: "application/json"})
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
+	entry, exists := c.GetEntry(API, key)
+	return entry.Val, exists
+}
+
+// AddEntry caches an entry in a namespace. An entry bigger than the
+// namespace's whole budget is not cached.
+func (c *Cache) AddEntry(ns, key string, entry Entry) {
 	c.mu.Lock()
-	entry, exists := c.entries[key]
-	c.mu.Unlock()
-	if exists {
-		return entry.val, true
+	defer c.mu.Unlock()
+	n := c.namespace(ns)
+	if n.budget > 0 && len(entry.Val) > n.budget {
+		return
+	}
+	n.remove(key)
+	n.evict(len(entry.Val))
+	n.entries[key] = cacheEntry{createdAt: time.Now(), element: n.order.PushBack(key), Entry: entry}
+	n.size += len(entry.Val)
+}
+
+func (c *Cache) GetEntry(ns, key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, exists := c.namespaces[ns]
+	if !exists {
+		return Entry{}, false
+	}
+	entry, exists := n.entries[key]
+	return entry.Entry, exists
+}
+
+// Size returns how many bytes a namespace holds.
+func (c *Cache) Size(ns string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, exists := c.namespaces[ns]; exists {
+		return n.size
+	}
+	return 0
+}
+
+func (c *Cache) namespace(ns string) *namespace {
+	n, exists := c.namespaces[ns]
+	if !exists {
+		n = &namespace{entries: make(map[string]cacheEntry), order: list.New(), ttl: c.interval}
+		c.namespaces[ns] = n
+	}
+	return n
+}
+
+func (n *namespace) remove(key string) {
+	if entry, exists := n.entries[key]; exists {
+		n.size -= len(entry.Val)
+		n.order.Remove(entry.element)
+		delete(n.entries, key)
+	}
+}
+
+// evict removes the oldest entries until incoming more bytes fit in the
+// budget.
+func (n *namespace) evict(incoming int) {
+	for n.budget > 0 && n.size+incoming > n.budget && n.order.Len() > 0 {
+		n.remove(n.order.Front().Value.(string))
 	}
-	return nil, false
 }
 
 func (c *Cache) reapLoop(interval time.Duration) {
@@ -48,9 +150,14 @@ func (c *Cache) reapLoop(interval time.Duration) {
 
 	for range ticker.C {
 		c.mu.Lock()
-		for key, val := range c.entries {
-			if time.Since(val.createdAt) > interval {
-				delete(c.entries, key)
+		for _, n := range c.namespaces {
+			if n.ttl <= 0 {
+				continue
+			}
+			for key, val := range n.entries {
+				if time.Since(val.createdAt) > n.ttl {
+					n.remove(key)
+				}
 			}
 		}
 		c.mu.Unlock()
@@ -92,3 +92,76 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestNamespaces(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	cache.Add("https://example.com", []byte("{}"))
+	cache.AddEntry(Images, "https://example.com", Entry{Val: []byte("png"), ContentType: "image/png"})
+
+	val, ok := cache.Get("https://example.com")
+	if !ok || string(val) != "{}" {
+		t.Errorf("expected to find the API entry, got %q", val)
+	}
+	entry, ok := cache.GetEntry(Images, "https://example.com")
+	if !ok || string(entry.Val) != "png" || entry.ContentType != "image/png" {
+		t.Errorf("expected to find the image entry, got %+v", entry)
+	}
+	if _, ok := cache.GetEntry("other", "https://example.com"); ok {
+		t.Errorf("expected to not find key in an unused namespace")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	cache.SetBudget(Images, 10)
+	cache.Add("https://example.com/api", []byte("0123456789abcdef"))
+	cases := []struct {
+		key string
+		val string
+	}{
+		{key: "first", val: "0123"},
+		{key: "second", val: "4567"},
+		{key: "third", val: "89ab"},
+	}
+	for _, c := range cases {
+		cache.AddEntry(Images, c.key, Entry{Val: []byte(c.val), ContentType: "image/png"})
+	}
+
+	if _, ok := cache.GetEntry(Images, "first"); ok {
+		t.Errorf("expected the oldest image to be evicted")
+	}
+	for _, key := range []string{"second", "third"} {
+		if _, ok := cache.GetEntry(Images, key); !ok {
+			t.Errorf("expected to find %s", key)
+		}
+	}
+	if size := cache.Size(Images); size != 8 {
+		t.Errorf("expected 8 bytes of images, got %d", size)
+	}
+	if _, ok := cache.Get("https://example.com/api"); !ok {
+		t.Errorf("expected images to not evict API entries")
+	}
+
+	cache.AddEntry(Images, "huge", Entry{Val: []byte("0123456789abcdef")})
+	if _, ok := cache.GetEntry(Images, "huge"); ok {
+		t.Errorf("expected an entry over the budget to not be cached")
+	}
+}
+
+func TestNamespaceTTL(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = baseTime + 5*time.Millisecond
+	cache := NewCache(baseTime)
+	cache.SetTTL(Images, 0)
+	cache.Add("https://example.com", []byte("{}"))
+	cache.AddEntry(Images, "https://example.com/sprite.png", Entry{Val: []byte("png"), ContentType: "image/png"})
+
+	time.Sleep(waitTime)
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected the API entry to expire")
+	}
+	if _, ok := cache.GetEntry(Images, "https://example.com/sprite.png"); !ok {
+		t.Errorf("expected the image to be kept without a TTL")
+	}
+}
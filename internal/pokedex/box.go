@@ -0,0 +1,90 @@
+package pokedex
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultBoxes = 8
+	BoxCapacity  = 30
+)
+
+var (
+	ErrNoSuchBox = errors.New("no such box")
+	ErrBoxFull   = errors.New("box is full")
+)
+
+func (p *Pokedex) ensureBoxes() {
+	for len(p.Boxes) < DefaultBoxes {
+		p.Boxes = append(p.Boxes, fmt.Sprintf("Box %d", len(p.Boxes)+1))
+	}
+}
+
+func (p *Pokedex) BoxNames() []string {
+	p.ensureBoxes()
+	return p.Boxes
+}
+
+// boxWithRoom returns the first box that is not full, adding a new box when
+// every existing one is.
+func (p *Pokedex) boxWithRoom() int {
+	p.ensureBoxes()
+	for i := range p.Boxes {
+		if len(p.BoxContents(i)) < BoxCapacity {
+			return i
+		}
+	}
+	p.Boxes = append(p.Boxes, fmt.Sprintf("Box %d", len(p.Boxes)+1))
+	return len(p.Boxes) - 1
+}
+
+func (p *Pokedex) BoxContents(box int) []*Caught {
+	contents := []*Caught{}
+	for _, c := range p.Instances() {
+		if c.Box == box {
+			contents = append(contents, c)
+		}
+	}
+	return contents
+}
+
+func (p *Pokedex) MoveToBox(id, box int) error {
+	p.ensureBoxes()
+	c, exists := p.Caught[id]
+	if !exists {
+		return ErrNoSuchPokemon
+	}
+	if box < 0 || box >= len(p.Boxes) {
+		return ErrNoSuchBox
+	}
+	if c.Box == box {
+		return nil
+	}
+	if len(p.BoxContents(box)) >= BoxCapacity {
+		return ErrBoxFull
+	}
+	p.dropFromParty(id)
+	c.Box = box
+	return nil
+}
+
+func (p *Pokedex) RenameBox(box int, name string) error {
+	p.ensureBoxes()
+	if box < 0 || box >= len(p.Boxes) {
+		return ErrNoSuchBox
+	}
+	p.Boxes[box] = name
+	return nil
+}
+
+// FindBox looks a box up by its number, counting from 1, or by its name.
+func (p *Pokedex) FindBox(query string) (int, error) {
+	p.ensureBoxes()
+	for i, name := range p.Boxes {
+		if query == name || query == fmt.Sprint(i+1) {
+			return i, nil
+		}
+	}
+	return 0, ErrNoSuchBox
+}
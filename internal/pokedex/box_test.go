@@ -0,0 +1,35 @@
+package pokedex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBoxes(t *testing.T) {
+	p := New()
+	for i := 0; i < MaxPartySize+BoxCapacity+1; i++ {
+		p.Catch(Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	}
+	if len(p.BoxContents(0)) != BoxCapacity {
+		t.Errorf("expected the first box to be full, got %d", len(p.BoxContents(0)))
+	}
+	if len(p.BoxContents(1)) != 1 {
+		t.Errorf("expected the overflow to go to the second box, got %d", len(p.BoxContents(1)))
+	}
+	if err := p.MoveToBox(MaxPartySize+BoxCapacity+1, 0); !errors.Is(err, ErrBoxFull) {
+		t.Errorf("expected ErrBoxFull, got %v", err)
+	}
+	if err := p.MoveToBox(1, 2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	p.RenameBox(2, "birds")
+	box, err := p.FindBox("birds")
+	if err != nil || box != 2 {
+		t.Errorf("expected to find box 2 by name, got %d, %v", box, err)
+	}
+	if _, err := p.FindBox("42"); !errors.Is(err, ErrNoSuchBox) {
+		t.Errorf("expected ErrNoSuchBox, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package pokedex
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var ErrNoSuchPokemon = errors.New("no caught pokemon with that id")
+
+type Caught struct {
+	ID         int       `json:"id"`
+	Species    string    `json:"species"`
+	Nickname   string    `json:"nickname,omitempty"`
+	Level      int       `json:"level"`
+	CaughtAt   time.Time `json:"caught_at"`
+	Location   string    `json:"location"`
+	Box        int       `json:"box"`
+	IVs        Stats     `json:"ivs"`
+	EVs        Stats     `json:"evs"`
+	Nature     Nature    `json:"nature"`
+	Moves      []string  `json:"moves"`
+	Experience int       `json:"experience"`
+	Shiny      bool      `json:"shiny,omitempty"`
+	// Form is the PokeAPI form name of a Pokemon caught in a regional or
+	// alternate form, like "unown-b". It is empty for the default form.
+	Form string `json:"form,omitempty"`
+	// Damage is how far the Pokemon's HP is below its maximum, so leveling up
+	// raises its current HP along with its max HP.
+	Damage int            `json:"damage"`
+	Status string         `json:"status,omitempty"`
+	PPUsed map[string]int `json:"pp_used,omitempty"`
+}
+
+// Heal restores the Pokemon's HP and PP and cures its status condition.
+func (c *Caught) Heal() {
+	c.Damage = 0
+	c.Status = ""
+	c.PPUsed = nil
+}
+
+func (c *Caught) Name() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Species
+}
+
+// Catch records a newly caught Pokemon and returns the new instance. It
+// joins the party if there is room and goes to a PC box otherwise.
+func (p *Pokedex) Catch(pokemon Pokemon, level int, location string, at time.Time) *Caught {
+	if p.Species == nil {
+		p.Species = make(map[string]Pokemon)
+	}
+	if p.Caught == nil {
+		p.Caught = make(map[int]*Caught)
+	}
+	if p.NextID < 1 {
+		p.NextID = 1
+	}
+
+	p.Species[pokemon.Name] = pokemon
+	p.MarkSeen(pokemon)
+	c := &Caught{
+		ID:       p.NextID,
+		Species:  pokemon.Name,
+		Level:    level,
+		CaughtAt: at,
+		Location: location,
+		Box:      PartyBox,
+		IVs:      Stats{},
+		EVs:      Stats{},
+	}
+	if len(p.Party) < MaxPartySize {
+		p.Party = append(p.Party, c.ID)
+	} else {
+		c.Box = p.boxWithRoom()
+	}
+	p.Caught[c.ID] = c
+	p.NextID++
+	return c
+}
+
+func (p *Pokedex) Release(id int) error {
+	if _, exists := p.Caught[id]; !exists {
+		return ErrNoSuchPokemon
+	}
+	p.dropFromParty(id)
+	delete(p.Caught, id)
+	return nil
+}
+
+func (p *Pokedex) Get(id int) (*Caught, bool) {
+	c, exists := p.Caught[id]
+	return c, exists
+}
+
+// Instances returns every caught Pokemon ordered by ID.
+func (p *Pokedex) Instances() []*Caught {
+	instances := make([]*Caught, 0, len(p.Caught))
+	for _, c := range p.Caught {
+		instances = append(instances, c)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID < instances[j].ID
+	})
+	return instances
+}
+
+// Find looks caught Pokemon up by instance ID, nickname or species name.
+func (p *Pokedex) Find(query string) []*Caught {
+	if id, err := strconv.Atoi(query); err == nil {
+		if c, exists := p.Caught[id]; exists {
+			return []*Caught{c}
+		}
+		return nil
+	}
+
+	found := []*Caught{}
+	for _, c := range p.Instances() {
+		if c.Species == query || c.Nickname == query {
+			found = append(found, c)
+		}
+	}
+	return found
+}
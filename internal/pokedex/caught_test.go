@@ -0,0 +1,54 @@
+package pokedex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCatchKeepsEveryInstance(t *testing.T) {
+	p := New()
+	first := p.Catch(Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	second := p.Catch(Pokemon{Name: "pidgey"}, 5, "kanto-route-1-area", time.Now())
+	if first.ID == second.ID {
+		t.Errorf("expected unique IDs, got %d twice", first.ID)
+	}
+	if len(p.Find("pidgey")) != 2 {
+		t.Errorf("expected to find 2 pidgey, got %d", len(p.Find("pidgey")))
+	}
+}
+
+func TestFind(t *testing.T) {
+	p := New()
+	p.Catch(Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	rattata := p.Catch(Pokemon{Name: "rattata"}, 2, "kanto-route-1-area", time.Now())
+	rattata.Nickname = "ratty"
+
+	cases := []struct {
+		query    string
+		expected int
+	}{
+		{query: "2", expected: 1},
+		{query: "ratty", expected: 1},
+		{query: "rattata", expected: 1},
+		{query: "7", expected: 0},
+		{query: "mewtwo", expected: 0},
+	}
+	for _, c := range cases {
+		if found := p.Find(c.query); len(found) != c.expected {
+			t.Errorf("%s: expected %d results, got %d", c.query, c.expected, len(found))
+		}
+	}
+}
+
+func TestMarkSeenCountsFormsAsTheirSpecies(t *testing.T) {
+	p := New()
+	rattata := Pokemon{Name: "rattata"}
+	rattata.Species.Name = "rattata"
+	alolan := Pokemon{Name: "rattata-alola"}
+	alolan.Species.Name = "rattata"
+	p.MarkSeen(alolan)
+	p.MarkSeen(rattata)
+	if len(p.Seen) != 1 || !p.Seen["rattata"] {
+		t.Errorf("expected only rattata to be seen, got %v", p.Seen)
+	}
+}
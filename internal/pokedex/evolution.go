@@ -0,0 +1,111 @@
+package pokedex
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+type ChainLink struct {
+	Species struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger struct {
+		Name string `json:"name"`
+	} `json:"trigger"`
+	MinLevel     *int `json:"min_level"`
+	MinHappiness *int `json:"min_happiness"`
+	Item         *struct {
+		Name string `json:"name"`
+	} `json:"item"`
+	HeldItem *struct {
+		Name string `json:"name"`
+	} `json:"held_item"`
+	KnownMove *struct {
+		Name string `json:"name"`
+	} `json:"known_move"`
+	TimeOfDay string `json:"time_of_day"`
+}
+
+// Trigger describes something that can make a Pokemon evolve: leveling up
+// to Level, having Item used on it, or being traded.
+type Trigger struct {
+	Name  string
+	Level int
+	Item  string
+}
+
+// Evolution returns the species a Pokemon of the given species evolves into
+// when the trigger happens, if any. Evolutions that depend on conditions
+// the CLI doesn't model, like friendship or the time of day, never happen.
+func (chain EvolutionChain) Evolution(species string, trigger Trigger) (string, bool) {
+	link, found := chain.Chain.find(species)
+	if !found {
+		return "", false
+	}
+	for _, next := range link.EvolvesTo {
+		for _, d := range next.EvolutionDetails {
+			if d.matches(trigger) {
+				return next.Species.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (link ChainLink) find(species string) (ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		if found, ok := next.find(species); ok {
+			return found, true
+		}
+	}
+	return ChainLink{}, false
+}
+
+func (d EvolutionDetail) matches(trigger Trigger) bool {
+	if d.Trigger.Name != trigger.Name {
+		return false
+	}
+	if d.MinHappiness != nil || d.HeldItem != nil || d.KnownMove != nil || d.TimeOfDay != "" {
+		return false
+	}
+	switch trigger.Name {
+	case "level-up":
+		return d.MinLevel != nil && trigger.Level >= *d.MinLevel
+	case "use-item":
+		return d.Item != nil && d.Item.Name == trigger.Item
+	case "trade":
+		return true
+	}
+	return false
+}
+
+// Evolve turns a caught Pokemon into another species. Its nickname, IVs,
+// EVs and moves stay the same, and so does its form if the evolved species
+// has a matching one, like rattata-alola evolving into raticate-alola.
+func (p *Pokedex) Evolve(id int, evolved Pokemon) error {
+	c, exists := p.Caught[id]
+	if !exists {
+		return ErrNoSuchPokemon
+	}
+	suffix := FormSuffix(c.Form, p.Species[c.Species].Species.Name)
+	p.Species[evolved.Name] = evolved
+	p.MarkSeen(evolved)
+	c.Species = evolved.Name
+	form := ""
+	for _, f := range evolved.Forms {
+		if suffix != "" && f.Name == evolved.Species.Name+"-"+suffix {
+			form = f.Name
+		}
+	}
+	c.Form = form
+	return nil
+}
@@ -0,0 +1,67 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const eeveeChain = `{
+	"id": 67,
+	"chain": {
+		"species": {"name": "eevee"},
+		"evolution_details": [],
+		"evolves_to": [
+			{"species": {"name": "vaporeon"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}], "evolves_to": []},
+			{"species": {"name": "espeon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}], "evolves_to": []}
+		]
+	}
+}`
+
+const abraChain = `{
+	"id": 26,
+	"chain": {
+		"species": {"name": "abra"},
+		"evolution_details": [],
+		"evolves_to": [{
+			"species": {"name": "kadabra"},
+			"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}],
+			"evolves_to": [{
+				"species": {"name": "alakazam"},
+				"evolution_details": [{"trigger": {"name": "trade"}}],
+				"evolves_to": []
+			}]
+		}]
+	}
+}`
+
+func TestEvolution(t *testing.T) {
+	eevee := EvolutionChain{}
+	abra := EvolutionChain{}
+	if err := json.Unmarshal([]byte(eeveeChain), &eevee); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(abraChain), &abra); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		chain    EvolutionChain
+		species  string
+		trigger  Trigger
+		expected string
+	}{
+		{chain: abra, species: "abra", trigger: Trigger{Name: "level-up", Level: 16}, expected: "kadabra"},
+		{chain: abra, species: "abra", trigger: Trigger{Name: "level-up", Level: 15}, expected: ""},
+		{chain: abra, species: "kadabra", trigger: Trigger{Name: "trade"}, expected: "alakazam"},
+		{chain: abra, species: "alakazam", trigger: Trigger{Name: "trade"}, expected: ""},
+		{chain: eevee, species: "eevee", trigger: Trigger{Name: "use-item", Item: "water-stone"}, expected: "vaporeon"},
+		{chain: eevee, species: "eevee", trigger: Trigger{Name: "use-item", Item: "fire-stone"}, expected: ""},
+		{chain: eevee, species: "eevee", trigger: Trigger{Name: "level-up", Level: 100}, expected: ""},
+	}
+	for _, c := range cases {
+		got, _ := c.chain.Evolution(c.species, c.trigger)
+		if got != c.expected {
+			t.Errorf("%s with %+v: expected %q, got %q", c.species, c.trigger, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package pokedex
+
+import (
+	"sort"
+)
+
+const MaxLevel = 100
+
+type GrowthRate struct {
+	Name   string            `json:"name"`
+	Levels []LevelExperience `json:"levels"`
+}
+
+type LevelExperience struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+}
+
+// ExperienceAt returns the total experience a Pokemon needs to reach a
+// level.
+func (g GrowthRate) ExperienceAt(level int) int {
+	for _, l := range g.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// LevelFor returns the level a Pokemon with the given total experience is.
+func (g GrowthRate) LevelFor(experience int) int {
+	levels := append([]LevelExperience{}, g.Levels...)
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Level < levels[j].Level
+	})
+	level := 1
+	for _, l := range levels {
+		if experience >= l.Experience {
+			level = l.Level
+		}
+	}
+	return level
+}
+
+// ExpYield is the experience for defeating a Pokemon, split between the
+// Pokemon that took part, using the formula from generations I to IV.
+func ExpYield(baseExperience, level int, trainer bool, participants int) int {
+	yield := float64(baseExperience*level) / 7
+	if trainer {
+		yield *= 1.5
+	}
+	return max(int(yield)/max(participants, 1), 1)
+}
+
+// AddEVs adds the effort values a defeated Pokemon gives, respecting the
+// per-stat and total limits.
+func (c *Caught) AddEVs(defeated Pokemon) {
+	if c.EVs == nil {
+		c.EVs = Stats{}
+	}
+	total := 0
+	for _, ev := range c.EVs {
+		total += ev
+	}
+	for _, s := range defeated.Stats {
+		gain := min(s.Effort, MaxEV-c.EVs[s.Stat.Name], MaxTotalEV-total)
+		if gain > 0 {
+			c.EVs[s.Stat.Name] += gain
+			total += gain
+		}
+	}
+}
+
+// LearnedAt returns the moves the Pokemon learns on reaching exactly the
+// given level.
+func LearnedAt(pokemon Pokemon, level int, versionGroup string) []string {
+	names := []string{}
+	for _, m := range LevelUpMoves(pokemon, versionGroup) {
+		if m.Level == level {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLevelFor(t *testing.T) {
+	medium := GrowthRate{Name: "medium", Levels: []LevelExperience{
+		{Level: 3, Experience: 27},
+		{Level: 1, Experience: 0},
+		{Level: 2, Experience: 8},
+		{Level: 4, Experience: 64},
+	}}
+	cases := []struct {
+		experience int
+		expected   int
+	}{
+		{experience: 0, expected: 1},
+		{experience: 26, expected: 2},
+		{experience: 27, expected: 3},
+		{experience: 1000, expected: 4},
+	}
+	for _, c := range cases {
+		if got := medium.LevelFor(c.experience); got != c.expected {
+			t.Errorf("%d exp: expected level %d, got %d", c.experience, c.expected, got)
+		}
+	}
+}
+
+func TestExpYield(t *testing.T) {
+	if got := ExpYield(50, 7, false, 1); got != 50 {
+		t.Errorf("expected 50, got %d", got)
+	}
+	if got := ExpYield(50, 7, true, 2); got != 37 {
+		t.Errorf("expected 37, got %d", got)
+	}
+}
+
+func TestAddEVs(t *testing.T) {
+	pidgey := Pokemon{}
+	err := json.Unmarshal([]byte(`{"stats": [{"base_stat": 56, "effort": 1, "stat": {"name": "speed"}}]}`), &pidgey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := &Caught{EVs: Stats{"speed": MaxEV}}
+	c.AddEVs(pidgey)
+	if c.EVs["speed"] != MaxEV {
+		t.Errorf("expected speed EVs to stay at %d, got %d", MaxEV, c.EVs["speed"])
+	}
+	c.EVs["speed"] = 0
+	c.AddEVs(pidgey)
+	if c.EVs["speed"] != 1 {
+		t.Errorf("expected 1 speed EV, got %d", c.EVs["speed"])
+	}
+}
@@ -0,0 +1,49 @@
+package pokedex
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// DefaultShinyOdds is the chance, one in DefaultShinyOdds, that a wild
+// Pokemon is shiny, as in generation VI onwards.
+const DefaultShinyOdds = 4096
+
+// Form is a PokeAPI /pokemon-form. Most Pokemon only have a default form
+// with an empty FormName; others have regional forms like "alola" or
+// alternate ones like Unown's letters.
+type Form struct {
+	Name         string `json:"name"`
+	FormName     string `json:"form_name"`
+	IsDefault    bool   `json:"is_default"`
+	IsBattleOnly bool   `json:"is_battle_only"`
+	IsMega       bool   `json:"is_mega"`
+	Sprites      struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+	} `json:"sprites"`
+}
+
+// Playable reports whether a wild Pokemon can be in the form. Battle-only
+// forms and megas only exist during a battle.
+func (f Form) Playable() bool {
+	return !f.IsBattleOnly && !f.IsMega
+}
+
+// FormSuffix returns what a form's name adds to its species' name, like
+// "alola" for rattata-alola, or "" if it adds nothing.
+func FormSuffix(form, species string) string {
+	suffix, ok := strings.CutPrefix(form, species+"-")
+	if !ok {
+		return ""
+	}
+	return suffix
+}
+
+// RollShiny reports whether a wild Pokemon is shiny at one in odds.
+func RollShiny(r *rand.Rand, odds int) bool {
+	if odds <= 1 {
+		return true
+	}
+	return r.Intn(odds) == 0
+}
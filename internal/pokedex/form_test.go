@@ -0,0 +1,76 @@
+package pokedex
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRollShiny(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	if !RollShiny(r, 1) {
+		t.Errorf("expected odds of 1 to always be shiny")
+	}
+	shinies := 0
+	for i := 0; i < 10000; i++ {
+		if RollShiny(r, 100) {
+			shinies++
+		}
+	}
+	if shinies < 50 || shinies > 200 {
+		t.Errorf("expected about 100 shinies at 1 in 100, got %d", shinies)
+	}
+}
+
+func TestFormSuffix(t *testing.T) {
+	cases := []struct {
+		form     string
+		species  string
+		expected string
+	}{
+		{form: "rattata-alola", species: "rattata", expected: "alola"},
+		{form: "unown-b", species: "unown", expected: "b"},
+		{form: "", species: "rattata", expected: ""},
+		{form: "mr-mime", species: "mr-mime", expected: ""},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if got := FormSuffix(c.form, c.species); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvolveKeepsMatchingForm(t *testing.T) {
+	pokemon := func(name, species string, forms ...string) Pokemon {
+		p := Pokemon{Name: name}
+		p.Species.Name = species
+		for _, form := range forms {
+			p.Forms = append(p.Forms, struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			}{Name: form})
+		}
+		return p
+	}
+	p := New()
+	alolan := p.Catch(pokemon("rattata-alola", "rattata", "rattata-alola"), 20, "route-1", time.Now())
+	alolan.Form = "rattata-alola"
+	shellos := p.Catch(pokemon("shellos", "shellos", "shellos-west", "shellos-east"), 30, "route-1", time.Now())
+	shellos.Form = "shellos-east"
+
+	if err := p.Evolve(alolan.ID, pokemon("raticate-alola", "raticate", "raticate-alola")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alolan.Form != "raticate-alola" {
+		t.Errorf("expected raticate-alola, got %q", alolan.Form)
+	}
+	if err := p.Evolve(shellos.ID, pokemon("gastrodon", "gastrodon", "gastrodon-west")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shellos.Form != "" {
+		t.Errorf("expected the form to be dropped without a matching one, got %q", shellos.Form)
+	}
+}
@@ -0,0 +1,63 @@
+package pokedex
+
+import (
+	"sort"
+)
+
+const MaxMoves = 4
+
+type LearnableMove struct {
+	Name  string
+	Level int
+}
+
+// LevelUpMoves lists the moves a Pokemon learns by leveling up in a version
+// group, in the order it learns them. If the Pokemon has no level-up moves
+// in that version group, the earliest level from any version group is used.
+func LevelUpMoves(pokemon Pokemon, versionGroup string) []LearnableMove {
+	moves := levelUpMoves(pokemon, versionGroup)
+	if len(moves) == 0 {
+		moves = levelUpMoves(pokemon, "")
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].Level < moves[j].Level
+	})
+	return moves
+}
+
+func levelUpMoves(pokemon Pokemon, versionGroup string) []LearnableMove {
+	moves := []LearnableMove{}
+	for _, m := range pokemon.Moves {
+		level := -1
+		for _, d := range m.VersionGroupDetails {
+			if d.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			if versionGroup != "" && d.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if level < 0 || d.LevelLearnedAt < level {
+				level = d.LevelLearnedAt
+			}
+		}
+		if level >= 0 {
+			moves = append(moves, LearnableMove{Name: m.Move.Name, Level: level})
+		}
+	}
+	return moves
+}
+
+// MovesAtLevel returns the moves a wild Pokemon of the given level knows:
+// the last four it learned by leveling up.
+func MovesAtLevel(pokemon Pokemon, level int, versionGroup string) []string {
+	names := []string{}
+	for _, m := range LevelUpMoves(pokemon, versionGroup) {
+		if m.Level <= level {
+			names = append(names, m.Name)
+		}
+	}
+	if len(names) > MaxMoves {
+		names = names[len(names)-MaxMoves:]
+	}
+	return names
+}
@@ -0,0 +1,82 @@
+package pokedex
+
+import (
+	"errors"
+)
+
+const (
+	MaxPartySize = 6
+	// PartyBox is the Box of a caught Pokemon that is in the party rather
+	// than in one of the PC boxes.
+	PartyBox = -1
+)
+
+var (
+	ErrPartyFull  = errors.New("party is full")
+	ErrInParty    = errors.New("pokemon is already in the party")
+	ErrNotInParty = errors.New("pokemon is not in the party")
+)
+
+func (p *Pokedex) PartyMembers() []*Caught {
+	members := []*Caught{}
+	for _, id := range p.Party {
+		if c, exists := p.Caught[id]; exists {
+			members = append(members, c)
+		}
+	}
+	return members
+}
+
+func (p *Pokedex) AddToParty(id int) error {
+	c, exists := p.Caught[id]
+	if !exists {
+		return ErrNoSuchPokemon
+	}
+	if c.Box == PartyBox {
+		return ErrInParty
+	}
+	if len(p.Party) >= MaxPartySize {
+		return ErrPartyFull
+	}
+	p.Party = append(p.Party, id)
+	c.Box = PartyBox
+	return nil
+}
+
+// RemoveFromParty sends a party member to the first PC box with room.
+func (p *Pokedex) RemoveFromParty(id int) error {
+	c, exists := p.Caught[id]
+	if !exists {
+		return ErrNoSuchPokemon
+	}
+	if c.Box != PartyBox {
+		return ErrNotInParty
+	}
+	p.dropFromParty(id)
+	c.Box = p.boxWithRoom()
+	return nil
+}
+
+func (p *Pokedex) SwapParty(a, b int) error {
+	i, j := p.partyIndex(a), p.partyIndex(b)
+	if i < 0 || j < 0 {
+		return ErrNotInParty
+	}
+	p.Party[i], p.Party[j] = p.Party[j], p.Party[i]
+	return nil
+}
+
+func (p *Pokedex) partyIndex(id int) int {
+	for i, member := range p.Party {
+		if member == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *Pokedex) dropFromParty(id int) {
+	if i := p.partyIndex(id); i >= 0 {
+		p.Party = append(p.Party[:i], p.Party[i+1:]...)
+	}
+}
@@ -0,0 +1,52 @@
+package pokedex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCatchFillsPartyFirst(t *testing.T) {
+	p := New()
+	for i := 0; i < MaxPartySize+1; i++ {
+		p.Catch(Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	}
+	if len(p.PartyMembers()) != MaxPartySize {
+		t.Errorf("expected a full party, got %d", len(p.PartyMembers()))
+	}
+	if len(p.BoxContents(0)) != 1 {
+		t.Errorf("expected the seventh catch to go to a box, got %d", len(p.BoxContents(0)))
+	}
+	if err := p.AddToParty(MaxPartySize + 1); !errors.Is(err, ErrPartyFull) {
+		t.Errorf("expected ErrPartyFull, got %v", err)
+	}
+}
+
+func TestPartyManagement(t *testing.T) {
+	p := New()
+	for i := 0; i < 3; i++ {
+		p.Catch(Pokemon{Name: "pidgey"}, 3, "kanto-route-1-area", time.Now())
+	}
+	if err := p.SwapParty(1, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Party[0] != 3 || p.Party[2] != 1 {
+		t.Errorf("expected 1 and 3 to be swapped, got %v", p.Party)
+	}
+	if err := p.RemoveFromParty(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Party) != 2 || len(p.BoxContents(0)) != 1 {
+		t.Errorf("expected 2 to move to a box, got party %v", p.Party)
+	}
+	if err := p.AddToParty(2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := p.AddToParty(2); !errors.Is(err, ErrInParty) {
+		t.Errorf("expected ErrInParty, got %v", err)
+	}
+	p.Release(3)
+	if len(p.Party) != 2 {
+		t.Errorf("expected released pokemon to leave the party, got %v", p.Party)
+	}
+}
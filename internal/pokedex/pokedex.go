@@ -244,6 +244,66 @@ type Pokemon struct {
 	Weight int `json:"weight"`
 }
 
+// Pokedex keeps the species data of every Pokemon the player has caught
+// separately from the individual Pokemon they own.
 type Pokedex struct {
-	Entries map[string]Pokemon
+	Species map[string]Pokemon `json:"species"`
+	Caught  map[int]*Caught    `json:"caught"`
+	NextID  int                `json:"next_id"`
+	Boxes   []string           `json:"boxes"`
+	Seen    map[string]bool    `json:"seen"`
+	Party   []int              `json:"party"`
+}
+
+func New() Pokedex {
+	p := Pokedex{
+		Species: make(map[string]Pokemon),
+		Caught:  make(map[int]*Caught),
+		NextID:  1,
+		Seen:    make(map[string]bool),
+	}
+	p.ensureBoxes()
+	return p
+}
+
+// MarkSeen records a Pokemon's species as seen, so that forms like
+// rattata-alola count as the species they belong to.
+func (p *Pokedex) MarkSeen(pokemon Pokemon) {
+	if p.Seen == nil {
+		p.Seen = make(map[string]bool)
+	}
+	species := pokemon.Species.Name
+	if species == "" {
+		species = pokemon.Name
+	}
+	p.Seen[species] = true
+}
+
+// HasCaught reports whether a species was ever caught, even if every
+// Pokemon of that species has since been released.
+func (p *Pokedex) HasCaught(species string) bool {
+	for _, pokemon := range p.Species {
+		if pokemon.Species.Name == species || pokemon.Name == species {
+			return true
+		}
+	}
+	return false
+}
+
+// CaughtSpecies returns how many species were ever caught. Every form of a
+// species counts once.
+func (p *Pokedex) CaughtSpecies() int {
+	species := map[string]bool{}
+	for _, pokemon := range p.Species {
+		name := pokemon.Species.Name
+		if name == "" {
+			name = pokemon.Name
+		}
+		species[name] = true
+	}
+	return len(species)
+}
+
+func (p *Pokedex) HasSeen(species string) bool {
+	return p.Seen[species] || p.HasCaught(species)
 }
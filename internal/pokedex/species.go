@@ -0,0 +1,27 @@
+package pokedex
+
+type Species struct {
+	Name           string `json:"name"`
+	CaptureRate    int    `json:"capture_rate"`
+	BaseHappiness  int    `json:"base_happiness"`
+	IsLegendary    bool   `json:"is_legendary"`
+	IsMythical     bool   `json:"is_mythical"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Generation struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+	GrowthRate struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"growth_rate"`
+	PokedexNumbers []struct {
+		EntryNumber int `json:"entry_number"`
+		Pokedex     struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+}
@@ -0,0 +1,70 @@
+package pokedex
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrUnknownSpriteVersion = errors.New("unknown sprite version")
+
+// DefaultSprites is the sprite version of the current games.
+const DefaultSprites = "default"
+
+// spriteVariants returns the normal and shiny front sprite of the pokemon for
+// every game. Games without shiny sprites, like red-blue, have no shiny one.
+func spriteVariants(pokemon Pokemon) map[string][2]string {
+	s := pokemon.Sprites
+	v := s.Versions
+	redBlue := v.GenerationI.RedBlue.FrontTransparent
+	if redBlue == "" {
+		redBlue = v.GenerationI.RedBlue.FrontDefault
+	}
+	yellow := v.GenerationI.Yellow.FrontTransparent
+	if yellow == "" {
+		yellow = v.GenerationI.Yellow.FrontDefault
+	}
+	return map[string][2]string{
+		DefaultSprites:            {s.FrontDefault, s.FrontShiny},
+		"red-blue":                {redBlue, ""},
+		"yellow":                  {yellow, ""},
+		"gold":                    {v.GenerationIi.Gold.FrontDefault, v.GenerationIi.Gold.FrontShiny},
+		"silver":                  {v.GenerationIi.Silver.FrontDefault, v.GenerationIi.Silver.FrontShiny},
+		"crystal":                 {v.GenerationIi.Crystal.FrontDefault, v.GenerationIi.Crystal.FrontShiny},
+		"ruby-sapphire":           {v.GenerationIii.RubySapphire.FrontDefault, v.GenerationIii.RubySapphire.FrontShiny},
+		"emerald":                 {v.GenerationIii.Emerald.FrontDefault, v.GenerationIii.Emerald.FrontShiny},
+		"firered-leafgreen":       {v.GenerationIii.FireredLeafgreen.FrontDefault, v.GenerationIii.FireredLeafgreen.FrontShiny},
+		"diamond-pearl":           {v.GenerationIv.DiamondPearl.FrontDefault, v.GenerationIv.DiamondPearl.FrontShiny},
+		"platinum":                {v.GenerationIv.Platinum.FrontDefault, v.GenerationIv.Platinum.FrontShiny},
+		"heartgold-soulsilver":    {v.GenerationIv.HeartgoldSoulsilver.FrontDefault, v.GenerationIv.HeartgoldSoulsilver.FrontShiny},
+		"black-white":             {v.GenerationV.BlackWhite.FrontDefault, v.GenerationV.BlackWhite.FrontShiny},
+		"x-y":                     {v.GenerationVi.XY.FrontDefault, v.GenerationVi.XY.FrontShiny},
+		"omegaruby-alphasapphire": {v.GenerationVi.OmegarubyAlphasapphire.FrontDefault, v.GenerationVi.OmegarubyAlphasapphire.FrontShiny},
+		"ultra-sun-ultra-moon":    {v.GenerationVii.UltraSunUltraMoon.FrontDefault, v.GenerationVii.UltraSunUltraMoon.FrontShiny},
+		"official-artwork":        {s.Other.OfficialArtwork.FrontDefault, s.Other.OfficialArtwork.FrontShiny},
+		"home":                    {s.Other.Home.FrontDefault, s.Other.Home.FrontShiny},
+	}
+}
+
+// SpriteVersions lists the versions SpriteURL accepts.
+func SpriteVersions() []string {
+	names := []string{}
+	for name := range spriteVariants(Pokemon{}) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SpriteURL returns the pokemon's front sprite in a game's style. A shiny
+// pokemon gets its normal sprite in games without shiny sprites. The URL is
+// empty when the pokemon isn't in that game.
+func SpriteURL(pokemon Pokemon, version string, shiny bool) (string, error) {
+	variants, ok := spriteVariants(pokemon)[version]
+	if !ok {
+		return "", ErrUnknownSpriteVersion
+	}
+	if shiny && variants[1] != "" {
+		return variants[1], nil
+	}
+	return variants[0], nil
+}
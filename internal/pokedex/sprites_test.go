@@ -0,0 +1,44 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSpriteURL(t *testing.T) {
+	pokemon := Pokemon{}
+	err := json.Unmarshal([]byte(`{"sprites": {
+		"front_default": "default.png",
+		"front_shiny": "shiny.png",
+		"versions": {"generation-i": {"red-blue": {"front_default": "rb.png", "front_transparent": "rb-transparent.png"}}}
+	}}`), &pokemon)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		version  string
+		shiny    bool
+		expected string
+		err      error
+	}{
+		{version: DefaultSprites, expected: "default.png"},
+		{version: DefaultSprites, shiny: true, expected: "shiny.png"},
+		{version: "red-blue", shiny: true, expected: "rb-transparent.png"},
+		{version: "x-y", expected: ""},
+		{version: "pokemon-snap", err: ErrUnknownSpriteVersion},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			url, err := SpriteURL(pokemon, c.version, c.shiny)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if url != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, url)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package pokedex
+
+import (
+	"math/rand"
+)
+
+const (
+	MaxIV      = 31
+	MaxEV      = 252
+	MaxTotalEV = 510
+)
+
+// StatNames lists the stats in the order the games show them, using
+// PokeAPI's names.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+type Stats map[string]int
+
+type Nature struct {
+	Name      string `json:"name"`
+	Increased string `json:"increased,omitempty"`
+	Decreased string `json:"decreased,omitempty"`
+}
+
+// Modifier returns the 1.1, 0.9 or 1 multiplier the nature applies to a
+// stat.
+func (n Nature) Modifier(stat string) float64 {
+	return float64(n.percent(stat)) / 100
+}
+
+// percent returns the nature's modifier as a percentage, so stats can be
+// computed with the games' integer math.
+func (n Nature) percent(stat string) int {
+	if n.Increased == n.Decreased {
+		return 100
+	}
+	switch stat {
+	case n.Increased:
+		return 110
+	case n.Decreased:
+		return 90
+	}
+	return 100
+}
+
+func BaseStats(pokemon Pokemon) Stats {
+	stats := Stats{}
+	for _, s := range pokemon.Stats {
+		stats[s.Stat.Name] = s.BaseStat
+	}
+	return stats
+}
+
+func RollIVs(r *rand.Rand) Stats {
+	ivs := Stats{}
+	for _, name := range StatNames {
+		ivs[name] = r.Intn(MaxIV + 1)
+	}
+	return ivs
+}
+
+// ComputeStat applies the standard stat formula from generation III on.
+func ComputeStat(stat string, base, iv, ev, level int, nature Nature) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		if base == 1 {
+			return 1
+		}
+		return core + level + 10
+	}
+	return (core + 5) * nature.percent(stat) / 100
+}
+
+func ComputeStats(pokemon Pokemon, ivs, evs Stats, level int, nature Nature) Stats {
+	base := BaseStats(pokemon)
+	stats := Stats{}
+	for _, name := range StatNames {
+		stats[name] = ComputeStat(name, base[name], ivs[name], evs[name], level, nature)
+	}
+	return stats
+}
+
+func (c *Caught) Stats(pokemon Pokemon) Stats {
+	return ComputeStats(pokemon, c.IVs, c.EVs, c.Level, c.Nature)
+}
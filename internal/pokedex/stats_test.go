@@ -0,0 +1,38 @@
+package pokedex
+
+import (
+	"testing"
+)
+
+func TestComputeStat(t *testing.T) {
+	// Garchomp at level 78 with an Adamant nature, the worked example from
+	// the games' stat formula.
+	adamant := Nature{Name: "adamant", Increased: "attack", Decreased: "special-attack"}
+	cases := []struct {
+		stat     string
+		base     int
+		iv       int
+		ev       int
+		expected int
+	}{
+		{stat: "hp", base: 108, iv: 24, ev: 74, expected: 289},
+		{stat: "attack", base: 130, iv: 12, ev: 190, expected: 278},
+		{stat: "defense", base: 95, iv: 30, ev: 91, expected: 193},
+		{stat: "special-attack", base: 80, iv: 16, ev: 48, expected: 135},
+		{stat: "special-defense", base: 85, iv: 23, ev: 84, expected: 171},
+		{stat: "speed", base: 102, iv: 5, ev: 23, expected: 171},
+	}
+	for _, c := range cases {
+		got := ComputeStat(c.stat, c.base, c.iv, c.ev, 78, adamant)
+		if got != c.expected {
+			t.Errorf("%s: expected %d, got %d", c.stat, c.expected, got)
+		}
+	}
+}
+
+func TestNeutralNature(t *testing.T) {
+	hardy := Nature{Name: "hardy", Increased: "attack", Decreased: "attack"}
+	if hardy.Modifier("attack") != 1 {
+		t.Errorf("expected a neutral nature to not change attack")
+	}
+}
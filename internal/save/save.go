@@ -0,0 +1,123 @@
+package save
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/inventory"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+type State struct {
+	Location  string              `json:"location"`
+	Inventory inventory.Inventory `json:"inventory"`
+	Pokedex   pokedex.Pokedex     `json:"pokedex"`
+	// VersionGroup is the game whose move sets are used, like "red-blue".
+	VersionGroup string `json:"version_group"`
+	// Badges are the gym badges the player has earned and Defeated the
+	// trainers they have beaten.
+	Badges   []string `json:"badges,omitempty"`
+	Defeated []string `json:"defeated,omitempty"`
+	// Seed is the RNG seed of the session that wrote the save, which can
+	// be passed to --seed to reproduce it.
+	Seed int64 `json:"seed,omitempty"`
+	// Migrated is how many pokemon were converted from a save written
+	// before caught pokemon were kept as their own instances.
+	Migrated int `json:"-"`
+}
+
+// legacyState is the layout of saves from before caught pokemon were kept as
+// their own instances, when the pokedex held one entry per species.
+type legacyState struct {
+	Pokedex struct {
+		Entries map[string]pokedex.Pokemon `json:"entries"`
+	} `json:"pokedex"`
+}
+
+// LegacyLevel is the level given to pokemon from old saves, which didn't
+// record levels.
+const LegacyLevel = 5
+
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".pokedexcli", "save.json"), nil
+}
+
+// Load reads a saved game. If there is no save yet the error wraps
+// os.ErrNotExist.
+func Load(path string) (State, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{}
+	err = json.Unmarshal(dat, &state)
+	if err != nil {
+		return State{}, err
+	}
+	if state.Pokedex.Caught == nil {
+		info, err := os.Stat(path)
+		if err != nil {
+			return State{}, err
+		}
+		err = migrate(&state, dat, info.ModTime())
+		if err != nil {
+			return State{}, err
+		}
+	}
+	return state, nil
+}
+
+// migrate converts the species entries of an old save into caught pokemon.
+// Where they were caught wasn't saved, and when is only known to be before
+// savedAt.
+func migrate(state *State, dat []byte, savedAt time.Time) error {
+	legacy := legacyState{}
+	err := json.Unmarshal(dat, &legacy)
+	if err != nil {
+		return err
+	}
+	if len(legacy.Pokedex.Entries) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for name := range legacy.Pokedex.Entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	state.Pokedex = pokedex.New()
+	for _, name := range names {
+		state.Pokedex.Catch(legacy.Pokedex.Entries[name], LegacyLevel, "", savedAt)
+	}
+	state.Migrated = len(names)
+	return nil
+}
+
+// Write saves the game, replacing any earlier save only once the new one has
+// been written in full.
+func Write(path string, state State) error {
+	dat, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, dat, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
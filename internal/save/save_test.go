@@ -0,0 +1,70 @@
+package save
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/inventory"
+)
+
+func TestWriteLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	state := State{
+		Location:  "viridian-city-area",
+		Inventory: inventory.New(),
+		Badges:    []string{"boulder-badge"},
+		Seed:      42,
+	}
+	if err := Write(path, state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Location != state.Location {
+		t.Errorf("expected location %s, got %s", state.Location, loaded.Location)
+	}
+	if loaded.Inventory.Count("poke-ball") != 5 || loaded.Inventory.Money != inventory.StartingMoney {
+		t.Errorf("expected inventory to be saved, got %+v", loaded.Inventory)
+	}
+	if len(loaded.Badges) != 1 || loaded.Seed != 42 {
+		t.Errorf("expected badges and seed to be saved, got %v and %d", loaded.Badges, loaded.Seed)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestLoadMigratesOldSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	old := `{"location": "pallet-town-area", "pokedex": {"entries": {
+		"pidgey": {"name": "pidgey", "species": {"name": "pidgey"}},
+		"bulbasaur": {"name": "bulbasaur", "species": {"name": "bulbasaur"}}
+	}}}`
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Migrated != 2 {
+		t.Errorf("expected 2 migrated pokemon, got %d", state.Migrated)
+	}
+	caught := state.Pokedex.Instances()
+	if len(caught) != 2 || caught[0].Species != "bulbasaur" || caught[1].Species != "pidgey" {
+		t.Fatalf("expected bulbasaur and pidgey to be caught, got %+v", caught)
+	}
+	if caught[0].Level != LegacyLevel || !state.Pokedex.HasSeen("pidgey") {
+		t.Errorf("expected migrated pokemon at level %d and seen, got %+v", LegacyLevel, caught[0])
+	}
+}
@@ -0,0 +1,149 @@
+package sim
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+)
+
+// MaxTurns ends a battle in a draw when neither team can finish the other,
+// like two pokemon that only know status moves.
+const MaxTurns = 500
+
+type Team struct {
+	Name     string
+	Strategy battle.Strategy
+	Members  []*battle.Combatant
+}
+
+// MemberStats counts what one team member did across all runs.
+type MemberStats struct {
+	KOs     int
+	Fainted int
+}
+
+type Result struct {
+	Runs  int
+	Wins  [2]int
+	Draws int
+	Turns int
+	// Members holds the stats of each team's members, in team order.
+	Members [2][]MemberStats
+}
+
+func (r Result) WinRate(side int) float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Wins[side]) / float64(r.Runs)
+}
+
+func (r Result) AverageTurns() float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Turns) / float64(r.Runs)
+}
+
+func newResult(teams [2]Team) Result {
+	r := Result{}
+	for i, team := range teams {
+		r.Members[i] = make([]MemberStats, len(team.Members))
+	}
+	return r
+}
+
+func (r *Result) merge(other Result) {
+	r.Runs += other.Runs
+	r.Draws += other.Draws
+	r.Turns += other.Turns
+	for side := range r.Wins {
+		r.Wins[side] += other.Wins[side]
+		for i, m := range other.Members[side] {
+			r.Members[side][i].KOs += m.KOs
+			r.Members[side][i].Fainted += m.Fainted
+		}
+	}
+}
+
+// Run battles team a against team b runs times, spread over workers
+// goroutines. Run i uses an RNG seeded with seed+i, so the result only
+// depends on the seed and not on how the runs were scheduled.
+func Run(a, b Team, runs, workers int, seed int64) Result {
+	teams := [2]Team{a, b}
+	jobs := make(chan int)
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := newResult(teams)
+			for i := range jobs {
+				play(teams, rand.New(rand.NewSource(seed+int64(i))), &r)
+			}
+			results <- r
+		}()
+	}
+	go func() {
+		for i := 0; i < runs; i++ {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	total := newResult(teams)
+	for r := range results {
+		total.merge(r)
+	}
+	return total
+}
+
+// play runs one battle between fresh copies of the teams. A knockout counts
+// for the pokemon that was out on the other side when it happened.
+func play(teams [2]Team, r *rand.Rand, result *Result) {
+	sides := [2]*battle.Side{}
+	for i, team := range teams {
+		members := make([]*battle.Combatant, len(team.Members))
+		for j, c := range team.Members {
+			members[j] = c.Clone()
+		}
+		sides[i] = battle.NewSide(team.Name, members)
+	}
+	b := battle.New(sides[0], sides[1], r)
+
+	for !b.Over() && b.Turns < MaxTurns {
+		active := [2]int{sides[0].Active, sides[1].Active}
+		standing := [2][]bool{}
+		for i, side := range sides {
+			for _, c := range side.Team {
+				standing[i] = append(standing[i], !c.Fainted())
+			}
+		}
+
+		b.Step([2]battle.Action{
+			teams[0].Strategy.Choose(b, battle.Player),
+			teams[1].Strategy.Choose(b, battle.Opponent),
+		})
+
+		for i, side := range sides {
+			for j, c := range side.Team {
+				if standing[i][j] && c.Fainted() {
+					result.Members[i][j].Fainted++
+					result.Members[1-i][active[1-i]].KOs++
+				}
+			}
+		}
+	}
+
+	result.Runs++
+	result.Turns += b.Turns
+	if winner := b.Winner(); winner >= 0 {
+		result.Wins[winner]++
+	} else {
+		result.Draws++
+	}
+}
@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func testCombatant(name string, types []string, level int, move battle.Move) *battle.Combatant {
+	stats := pokedex.Stats{"hp": 100, "attack": 80, "defense": 80, "special-attack": 80, "special-defense": 80, "speed": 80}
+	return &battle.Combatant{
+		Name:  name,
+		Level: level,
+		Types: types,
+		Stats: stats,
+		HP:    stats["hp"],
+		Moves: []*battle.MoveSlot{{Move: move, PP: move.PP}},
+	}
+}
+
+var (
+	thunderbolt = battle.Move{Name: "thunderbolt", Type: "electric", Power: 90, Accuracy: 100, PP: 15, DamageClass: "special"}
+	waterGun    = battle.Move{Name: "water-gun", Type: "water", Power: 40, Accuracy: 100, PP: 25, DamageClass: "special"}
+)
+
+func testTeams() (Team, Team) {
+	a := Team{Name: "A", Strategy: battle.Greedy{}, Members: []*battle.Combatant{
+		testCombatant("pikachu", []string{"electric"}, 50, thunderbolt),
+	}}
+	b := Team{Name: "B", Strategy: battle.Random{}, Members: []*battle.Combatant{
+		testCombatant("squirtle", []string{"water"}, 50, waterGun),
+		testCombatant("poliwag", []string{"water"}, 50, waterGun),
+	}}
+	return a, b
+}
+
+func TestRun(t *testing.T) {
+	a, b := testTeams()
+	result := Run(a, b, 200, 4, 1)
+	if result.Runs != 200 {
+		t.Fatalf("expected 200 runs, got %d", result.Runs)
+	}
+	if result.WinRate(battle.Player) < 0.9 {
+		t.Errorf("expected pikachu to win nearly every battle, got a win rate of %v", result.WinRate(battle.Player))
+	}
+	if result.Wins[0]+result.Wins[1]+result.Draws != result.Runs {
+		t.Errorf("expected every run to end in a win or a draw, got %+v", result)
+	}
+	if a.Members[0].HP != a.Members[0].MaxHP() {
+		t.Errorf("expected the teams to be left untouched")
+	}
+	kos := result.Members[0][0].KOs
+	fainted := result.Members[1][0].Fainted + result.Members[1][1].Fainted
+	if kos != fainted {
+		t.Errorf("expected pikachu's %d KOs to match the %d fainted opponents", kos, fainted)
+	}
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	a, b := testTeams()
+	one := Run(a, b, 100, 1, 7)
+	many := Run(a, b, 100, 8, 7)
+	if one.Wins != many.Wins || one.Turns != many.Turns {
+		t.Errorf("expected the same result with any number of workers, got %+v and %+v", one, many)
+	}
+}
@@ -0,0 +1,165 @@
+package sprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+)
+
+// Mode is how many colours the terminal can show.
+type Mode int
+
+const (
+	ASCII Mode = iota
+	Color256
+	TrueColor
+)
+
+const reset = "\x1b[0m"
+
+// asciiRamp goes from light to dark, so dark outlines come out dense.
+const asciiRamp = ".:-=+*#%@"
+
+// DetectMode guesses the terminal's colour support from the environment.
+// NO_COLOR turns colours off.
+func DetectMode() Mode {
+	if os.Getenv("NO_COLOR") != "" {
+		return ASCII
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Color256
+	}
+	return ASCII
+}
+
+// ParseMode reads a mode name: truecolor, 256 or ascii.
+func ParseMode(name string) (Mode, error) {
+	switch name {
+	case "truecolor":
+		return TrueColor, nil
+	case "256":
+		return Color256, nil
+	case "ascii":
+		return ASCII, nil
+	}
+	return ASCII, fmt.Errorf("unknown sprite mode %s", name)
+}
+
+// Render draws an image with one character for every two rows of pixels,
+// after cropping its transparent border and shrinking it to at most maxWidth
+// columns. In colour modes each character is an upper half block coloured
+// with the top pixel over the bottom one.
+func Render(img image.Image, mode Mode, maxWidth int) string {
+	bounds := crop(img)
+	if bounds.Empty() {
+		return ""
+	}
+	scale := 1
+	for bounds.Dx()/scale > maxWidth {
+		scale++
+	}
+
+	var sb strings.Builder
+	width, height := bounds.Dx()/scale, bounds.Dy()/scale
+	for y := 0; y < height; y += 2 {
+		for x := 0; x < width; x++ {
+			top := pixel(img, bounds, scale, x, y)
+			bottom := color.RGBA{}
+			if y+1 < height {
+				bottom = pixel(img, bounds, scale, x, y+1)
+			}
+			sb.WriteString(cell(top, bottom, mode))
+		}
+		if mode != ASCII {
+			sb.WriteString(reset)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// crop returns the smallest rectangle holding every visible pixel.
+func crop(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	visible := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a > 0 {
+				visible = visible.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return visible
+}
+
+func pixel(img image.Image, bounds image.Rectangle, scale, x, y int) color.RGBA {
+	return color.RGBAModel.Convert(img.At(bounds.Min.X+x*scale, bounds.Min.Y+y*scale)).(color.RGBA)
+}
+
+func cell(top, bottom color.RGBA, mode Mode) string {
+	if mode == ASCII {
+		return asciiCell(top, bottom)
+	}
+	switch {
+	case top.A == 0 && bottom.A == 0:
+		return reset + " "
+	case bottom.A == 0:
+		return reset + foreground(top, mode) + "▀"
+	case top.A == 0:
+		return reset + foreground(bottom, mode) + "▄"
+	}
+	return foreground(top, mode) + background(bottom, mode) + "▀"
+}
+
+func asciiCell(top, bottom color.RGBA) string {
+	visible := []color.RGBA{}
+	for _, c := range []color.RGBA{top, bottom} {
+		if c.A > 0 {
+			visible = append(visible, c)
+		}
+	}
+	if len(visible) == 0 {
+		return " "
+	}
+	light := 0.0
+	for _, c := range visible {
+		light += luminance(c)
+	}
+	light /= float64(len(visible))
+	index := int((1 - light) * float64(len(asciiRamp)-1))
+	return string(asciiRamp[index])
+}
+
+// luminance is how light a colour looks, from 0 to 1.
+func luminance(c color.RGBA) float64 {
+	return (0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)) / 255
+}
+
+func foreground(c color.RGBA, mode Mode) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", xterm256(c))
+}
+
+func background(c color.RGBA, mode Mode) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", xterm256(c))
+}
+
+// xterm256 maps a colour onto the 6x6x6 colour cube of the 256-colour
+// palette.
+func xterm256(c color.RGBA) int {
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+	return 16 + 36*level(c.R) + 6*level(c.G) + level(c.B)
+}
@@ -0,0 +1,51 @@
+package sprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// testImage is a 4x4 red square with a transparent border of one pixel.
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 6, 6))
+	for y := 1; y < 5; y++ {
+		for x := 1; x < 5; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	return img
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		mode     Mode
+		maxWidth int
+		lines    int
+		contains string
+	}{
+		{mode: TrueColor, maxWidth: 80, lines: 2, contains: "\x1b[38;2;255;0;0m\x1b[48;2;255;0;0m▀"},
+		{mode: Color256, maxWidth: 80, lines: 2, contains: "\x1b[38;5;196m"},
+		{mode: ASCII, maxWidth: 80, lines: 2, contains: "****"},
+		{mode: ASCII, maxWidth: 2, lines: 1, contains: "**"},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			out := Render(testImage(), c.mode, c.maxWidth)
+			if lines := strings.Count(out, "\n"); lines != c.lines {
+				t.Errorf("expected %d lines, got %d: %q", c.lines, lines, out)
+			}
+			if !strings.Contains(out, c.contains) {
+				t.Errorf("expected %q in %q", c.contains, out)
+			}
+		})
+	}
+}
+
+func TestRenderTransparent(t *testing.T) {
+	if out := Render(image.NewRGBA(image.Rect(0, 0, 4, 4)), TrueColor, 80); out != "" {
+		t.Errorf("expected nothing for a transparent image, got %q", out)
+	}
+}
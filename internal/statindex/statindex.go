@@ -0,0 +1,99 @@
+package statindex
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+// Total is the key of the base stat total in an index.
+const Total = "total"
+
+// Index holds the base stats of every species, built locally so stats can be
+// ranked without asking PokeAPI for every species each time.
+type Index struct {
+	Pokemon map[string]pokedex.Stats `json:"pokemon"`
+}
+
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".pokedexcli", "stat-index.json"), nil
+}
+
+// Add records a pokemon's base stats and their total. Only the default
+// variety of each species is indexed, so megas, gigantamax and other
+// alternate forms don't skew the ranking.
+func (idx *Index) Add(pokemon pokedex.Pokemon) {
+	if !pokemon.IsDefault {
+		return
+	}
+	if idx.Pokemon == nil {
+		idx.Pokemon = make(map[string]pokedex.Stats)
+	}
+	stats := pokedex.BaseStats(pokemon)
+	stats[Total] = TotalOf(stats)
+	idx.Pokemon[pokemon.Name] = stats
+}
+
+func TotalOf(stats pokedex.Stats) int {
+	total := 0
+	for _, name := range pokedex.StatNames {
+		total += stats[name]
+	}
+	return total
+}
+
+// Percentile returns the percentage of indexed pokemon whose stat is lower
+// than value.
+func (idx Index) Percentile(stat string, value int) float64 {
+	if len(idx.Pokemon) == 0 {
+		return 0
+	}
+	lower := 0
+	for _, stats := range idx.Pokemon {
+		if stats[stat] < value {
+			lower++
+		}
+	}
+	return 100 * float64(lower) / float64(len(idx.Pokemon))
+}
+
+// Load reads an index. If none has been built yet the error wraps
+// os.ErrNotExist.
+func Load(path string) (Index, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return Index{}, err
+	}
+
+	idx := Index{}
+	err = json.Unmarshal(dat, &idx)
+	if err != nil {
+		return Index{}, err
+	}
+	return idx, nil
+}
+
+func Write(path string, idx Index) error {
+	dat, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, dat, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
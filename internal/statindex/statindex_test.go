@@ -0,0 +1,71 @@
+package statindex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+func testIndex() Index {
+	idx := Index{Pokemon: map[string]pokedex.Stats{}}
+	for i, speed := range []int{20, 40, 60, 80, 100} {
+		stats := pokedex.Stats{"speed": speed}
+		stats[Total] = TotalOf(stats)
+		idx.Pokemon[fmt.Sprintf("pokemon-%d", i)] = stats
+	}
+	return idx
+}
+
+func TestPercentile(t *testing.T) {
+	idx := testIndex()
+	cases := []struct {
+		value    int
+		expected float64
+	}{
+		{value: 10, expected: 0},
+		{value: 60, expected: 40},
+		{value: 61, expected: 60},
+		{value: 200, expected: 100},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if got := idx.Percentile("speed", c.value); got != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+	if got := idx.Percentile(Total, 61); got != 60 {
+		t.Errorf("expected totals to be indexed, got %v", got)
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stat-index.json")
+	if _, err := Load(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+	if err := Write(path, testIndex()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	idx, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(idx.Pokemon) != 5 {
+		t.Errorf("expected 5 pokemon, got %d", len(idx.Pokemon))
+	}
+}
+
+func TestAddOnlyIndexesDefaultVarieties(t *testing.T) {
+	idx := Index{}
+	idx.Add(pokedex.Pokemon{Name: "venusaur", IsDefault: true})
+	idx.Add(pokedex.Pokemon{Name: "venusaur-mega"})
+	idx.Add(pokedex.Pokemon{Name: "venusaur-gmax"})
+	if _, ok := idx.Pokemon["venusaur"]; !ok || len(idx.Pokemon) != 1 {
+		t.Errorf("expected only venusaur to be indexed, got %v", idx.Pokemon)
+	}
+}
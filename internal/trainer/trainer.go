@@ -0,0 +1,100 @@
+package trainer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+)
+
+//go:embed trainers.json
+var trainersJSON []byte
+
+type Member struct {
+	Species string   `json:"species"`
+	Level   int      `json:"level"`
+	Moves   []string `json:"moves,omitempty"`
+}
+
+type Trainer struct {
+	Name       string `json:"name"`
+	Title      string `json:"title"`
+	Location   string `json:"location"`
+	Difficulty string `json:"difficulty"`
+	// AI names the battle strategy the trainer uses. Without one it follows
+	// from the difficulty.
+	AI             string   `json:"ai,omitempty"`
+	Reward         int      `json:"reward"`
+	Badge          string   `json:"badge,omitempty"`
+	RequiresBadges []string `json:"requires_badges,omitempty"`
+	Team           []Member `json:"team"`
+}
+
+// IV returns the IV every stat of the trainer's team has. Tougher trainers
+// raise stronger Pokemon.
+func (t Trainer) IV() int {
+	switch t.Difficulty {
+	case "easy":
+		return 0
+	case "hard":
+		return 31
+	}
+	return 15
+}
+
+// Strategy returns how the trainer picks its moves in battle.
+func (t Trainer) Strategy() battle.Strategy {
+	if t.AI != "" {
+		return battle.Strategies[t.AI]
+	}
+	switch t.Difficulty {
+	case "easy":
+		return battle.Random{}
+	case "hard":
+		return battle.Strategies["minimax"]
+	}
+	return battle.Greedy{}
+}
+
+type Roster struct {
+	trainers map[string]Trainer
+}
+
+// Load reads the bundled trainer data.
+func Load() (*Roster, error) {
+	trainers := []Trainer{}
+	err := json.Unmarshal(trainersJSON, &trainers)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Roster{trainers: make(map[string]Trainer)}
+	for _, t := range trainers {
+		if _, known := battle.Strategies[t.AI]; t.AI != "" && !known {
+			return nil, fmt.Errorf("trainer %s has unknown ai %s", t.Name, t.AI)
+		}
+		r.trainers[t.Name] = t
+	}
+	return r, nil
+}
+
+func (r *Roster) Get(name string) (Trainer, bool) {
+	t, exists := r.trainers[name]
+	return t, exists
+}
+
+// At returns the trainers found in an area, ordered by name.
+func (r *Roster) At(location string) []Trainer {
+	found := []Trainer{}
+	for _, t := range r.trainers {
+		if t.Location == location {
+			found = append(found, t)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Name < found[j].Name
+	})
+	return found
+}
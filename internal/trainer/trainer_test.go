@@ -0,0 +1,58 @@
+package trainer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/world"
+)
+
+func TestTrainersAreOnTheMap(t *testing.T) {
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w, err := world.Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tr := range r.trainers {
+		if _, exists := w.Area(tr.Location); !exists {
+			t.Errorf("%s is in unknown area %s", tr.Name, tr.Location)
+		}
+		if len(tr.Team) == 0 {
+			t.Errorf("%s has no pokemon", tr.Name)
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := r.At("pewter-city-area")
+	if len(found) != 1 || found[0].Badge != "boulder-badge" {
+		t.Errorf("expected brock in pewter-city-area, got %+v", found)
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	cases := []struct {
+		trainer  Trainer
+		expected battle.Strategy
+	}{
+		{trainer: Trainer{Difficulty: "easy"}, expected: battle.Random{}},
+		{trainer: Trainer{Difficulty: "normal"}, expected: battle.Greedy{}},
+		{trainer: Trainer{Difficulty: "hard"}, expected: battle.Strategies["minimax"]},
+		{trainer: Trainer{Difficulty: "easy", AI: "greedy"}, expected: battle.Greedy{}},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if got := c.trainer.Strategy(); got != c.expected {
+				t.Errorf("expected %#v, got %#v", c.expected, got)
+			}
+		})
+	}
+}
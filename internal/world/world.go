@@ -0,0 +1,87 @@
+package world
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+//go:embed routes.json
+var routesJSON []byte
+
+type Area struct {
+	Name          string `json:"name"`
+	Location      string `json:"location"`
+	Region        string `json:"region"`
+	PokemonCenter bool   `json:"pokemon_center"`
+	// RequiresBadge is the gym badge the player needs to enter the area.
+	RequiresBadge string   `json:"requires_badge,omitempty"`
+	Connections   []string `json:"connections"`
+}
+
+type World struct {
+	Start string
+	areas map[string]Area
+}
+
+type routeData struct {
+	Start string `json:"start"`
+	Areas []Area `json:"areas"`
+}
+
+// Load builds the world from the bundled route data. Connections only need
+// to be listed on one of the two areas they join; Load adds the way back.
+func Load() (*World, error) {
+	data := routeData{}
+	err := json.Unmarshal(routesJSON, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &World{Start: data.Start, areas: make(map[string]Area)}
+	for _, area := range data.Areas {
+		w.areas[area.Name] = area
+	}
+	for _, area := range data.Areas {
+		for _, name := range area.Connections {
+			other, exists := w.areas[name]
+			if !exists {
+				return nil, fmt.Errorf("area %s is connected to unknown area %s", area.Name, name)
+			}
+			if !contains(other.Connections, area.Name) {
+				other.Connections = append(other.Connections, area.Name)
+				w.areas[name] = other
+			}
+		}
+	}
+	if _, exists := w.areas[w.Start]; !exists {
+		return nil, fmt.Errorf("start area %s does not exist", w.Start)
+	}
+	return w, nil
+}
+
+func (w *World) Area(name string) (Area, bool) {
+	area, exists := w.areas[name]
+	return area, exists
+}
+
+func (w *World) Connected(from, to string) bool {
+	area, exists := w.areas[from]
+	return exists && contains(area.Connections, to)
+}
+
+func (w *World) Neighbours(name string) []string {
+	neighbours := append([]string{}, w.areas[name].Connections...)
+	sort.Strings(neighbours)
+	return neighbours
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
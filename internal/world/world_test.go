@@ -0,0 +1,64 @@
+package world
+
+import (
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	w, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := w.Area(w.Start); !ok {
+		t.Errorf("expected start area %s to exist", w.Start)
+	}
+}
+
+func TestConnectionsAreTwoWay(t *testing.T) {
+	w, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := []struct {
+		from string
+		to   string
+	}{
+		{from: "pallet-town-area", to: "kanto-route-1-area"},
+		{from: "kanto-route-1-area", to: "pallet-town-area"},
+		{from: "viridian-city-area", to: "kanto-route-1-area"},
+	}
+	for _, c := range cases {
+		if !w.Connected(c.from, c.to) {
+			t.Errorf("expected %s to be connected to %s", c.from, c.to)
+		}
+	}
+	if w.Connected("pallet-town-area", "cerulean-city-area") {
+		t.Errorf("expected pallet-town-area to not be connected to cerulean-city-area")
+	}
+}
+
+func TestPokemonCenters(t *testing.T) {
+	w, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if area, _ := w.Area("viridian-city-area"); !area.PokemonCenter {
+		t.Errorf("expected viridian-city-area to have a pokemon center")
+	}
+	if area, _ := w.Area("kanto-route-1-area"); area.PokemonCenter {
+		t.Errorf("expected kanto-route-1-area to not have a pokemon center")
+	}
+}
+
+func TestRequiresBadge(t *testing.T) {
+	w, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if area, _ := w.Area("kanto-route-3-area"); area.RequiresBadge != "boulder-badge" {
+		t.Errorf("expected kanto-route-3-area to require the boulder-badge, got %q", area.RequiresBadge)
+	}
+	if area, _ := w.Area(w.Start); area.RequiresBadge != "" {
+		t.Errorf("expected the start area to require no badge, got %q", area.RequiresBadge)
+	}
+}
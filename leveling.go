@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+)
+
+// rewardKnockouts gives experience and EVs to the player's pokemon for
+// every opponent in knockedOut, split between the pokemon that battled it.
+func rewardKnockouts(cfg *config, knockedOut []*battle.Combatant, trainer bool) error {
+	for _, opponent := range knockedOut {
+		defeated, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+opponent.Species, cfg.cache)
+		if err != nil {
+			return err
+		}
+		participants := cfg.battle.Participants(opponent)
+		exp := pokedex.ExpYield(defeated.BaseExperience, opponent.Level, trainer, len(participants))
+		for _, combatant := range participants {
+			c, exists := cfg.pokedex.Get(combatant.ID)
+			if !exists {
+				continue
+			}
+			c.AddEVs(defeated)
+			if err := gainExperience(cfg, c, combatant, exp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gainExperience(cfg *config, c *pokedex.Caught, combatant *battle.Combatant, exp int) error {
+	pokemon := cfg.pokedex.Species[c.Species]
+	growth, err := growthRate(cfg, pokemon)
+	if err != nil {
+		return err
+	}
+	c.Experience = max(c.Experience, growth.ExperienceAt(c.Level)) + exp
+	fmt.Printf("%s gained %d Exp. Points!\n", c.Name(), exp)
+
+	newLevel := min(growth.LevelFor(c.Experience), pokedex.MaxLevel)
+	if c.Level < newLevel && !slices.Contains(cfg.leveledUp, c.ID) {
+		cfg.leveledUp = append(cfg.leveledUp, c.ID)
+	}
+	for c.Level < newLevel {
+		c.Level++
+		fmt.Printf("%s grew to level %d!\n", c.Name(), c.Level)
+		for _, move := range pokedex.LearnedAt(pokemon, c.Level, cfg.versionGroup) {
+			if err := learnMove(cfg, c, combatant, move); err != nil {
+				return err
+			}
+		}
+	}
+
+	if combatant != nil {
+		stats := c.Stats(pokemon)
+		combatant.HP = max(combatant.HP+stats["hp"]-combatant.MaxHP(), 0)
+		combatant.Stats = stats
+		combatant.Level = c.Level
+	}
+	return nil
+}
+
+func growthRate(cfg *config, pokemon pokedex.Pokemon) (pokedex.GrowthRate, error) {
+	species, err := cfg.client.GetPokemonSpecies(pokemon.Species.URL, cfg.cache)
+	if err != nil {
+		return pokedex.GrowthRate{}, err
+	}
+	return cfg.client.GetGrowthRate(species.GrowthRate.URL, cfg.cache)
+}
+
+// learnMove teaches a move, asking which move to forget when the pokemon
+// already knows four. The battle combatant, if any, learns it too.
+func learnMove(cfg *config, c *pokedex.Caught, combatant *battle.Combatant, move string) error {
+	for _, known := range c.Moves {
+		if known == move {
+			return nil
+		}
+	}
+
+	index := len(c.Moves)
+	if len(c.Moves) >= pokedex.MaxMoves {
+		fmt.Printf("%s wants to learn %s, but %s already knows %d moves: %s\n", c.Name(), move, c.Name(), len(c.Moves), strings.Join(c.Moves, ", "))
+		fmt.Printf("Which move should be forgotten? (leave blank to not learn %s) ", move)
+		forget := ""
+		if cfg.scanner.Scan() {
+			forget = strings.TrimSpace(cfg.scanner.Text())
+		}
+		index = -1
+		for i, known := range c.Moves {
+			if known == forget {
+				index = i
+			}
+		}
+		if index < 0 {
+			fmt.Printf("%s did not learn %s.\n", c.Name(), move)
+			return nil
+		}
+		fmt.Printf("1, 2 and... Poof! %s forgot %s and...\n", c.Name(), forget)
+	}
+
+	if combatant != nil {
+		slots, err := loadMoves(cfg, []string{move})
+		if err != nil {
+			return err
+		}
+		if index < len(combatant.Moves) {
+			combatant.Moves[index] = slots[0]
+		} else {
+			combatant.Moves = append(combatant.Moves, slots[0])
+		}
+	}
+	if index < len(c.Moves) {
+		c.Moves[index] = move
+	} else {
+		c.Moves = append(c.Moves, move)
+	}
+	fmt.Printf("%s learned %s!\n", c.Name(), move)
+	return nil
+}
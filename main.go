@@ -6,12 +6,23 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/battle"
+	"github.com/samersawan/pokedexcli/internal/capture"
+	"github.com/samersawan/pokedexcli/internal/chart"
+	"github.com/samersawan/pokedexcli/internal/inventory"
 	"github.com/samersawan/pokedexcli/internal/pokecache"
 	"github.com/samersawan/pokedexcli/internal/pokedex"
+	"github.com/samersawan/pokedexcli/internal/save"
+	"github.com/samersawan/pokedexcli/internal/sprite"
+	"github.com/samersawan/pokedexcli/internal/statindex"
+	"github.com/samersawan/pokedexcli/internal/trainer"
+	"github.com/samersawan/pokedexcli/internal/world"
 )
 
 type config struct {
@@ -20,9 +31,47 @@ type config struct {
 	cache   *pokecache.Cache
 	client  api.Client
 	args    []string
-	pokedex pokedex.Pokedex
+	scanner *bufio.Scanner
+	// rng drives every random roll of the session, so a session started
+	// with the same seed plays out the same way.
+	rng       *rand.Rand
+	seed      int64
+	shinyOdds int
+
+	spriteVersion string
+	colorMode     sprite.Mode
+	pokedex       pokedex.Pokedex
+	world         *world.World
+	location      string
+	wild          *wildPokemon
+	battle        *battle.Battle
+	// opponent is the trainer being battled, or nil in a wild battle.
+	opponent *trainer.Trainer
+	// leveledUp holds the IDs of the pokemon that leveled up during the
+	// current battle, which may evolve once it ends.
+	leveledUp []int
+	// statIndex ranks base stats against every species once the index
+	// command has built it.
+	statIndex     statindex.Index
+	statIndexPath string
+
+	inventory    inventory.Inventory
+	savePath     string
+	versionGroup string
+	trainers     *trainer.Roster
+	badges       []string
+	defeated     []string
+
+	region          string
+	regionLocations []string
+	regionOffset    int
 }
 
+// imageCacheBudget is how many bytes of sprites are kept in the cache.
+// Sprites never change, so they don't expire and are only evicted to stay
+// within the budget.
+const imageCacheBudget = 4 << 20
+
 type cliCommand struct {
 	name        string
 	description string
@@ -38,12 +87,22 @@ func getCommands() map[string]cliCommand {
 		},
 		"exit": {
 			name:        "exit",
-			description: "Exit the Pokedex",
+			description: "Saves your game and exits the Pokedex",
 			callback:    commandExit,
 		},
+		"save": {
+			name:        "save",
+			description: "Saves your game",
+			callback:    commandSave,
+		},
+		"game": {
+			name:        "game",
+			description: "Shows the game (PokeAPI version group) whose move sets your pokemon learn from and the session's random seed. Takes a version group like red-blue or gold-silver to change it",
+			callback:    commandGame,
+		},
 		"map": {
 			name:        "map",
-			description: "Displays the names of 20 location areas in the Pokemon world. Each subsequent call to map displays the next 20 locations",
+			description: "Displays the names of 20 location areas in the Pokemon world. Each subsequent call to map displays the next 20 locations. Use --region <name> to list a region's locations with their areas instead, or --region all to go back",
 			callback:    commandMap,
 		},
 		"mapb": {
@@ -51,32 +110,137 @@ func getCommands() map[string]cliCommand {
 			description: "Displays the names of the previous 20 locations",
 			callback:    commandMapb,
 		},
+		"regions": {
+			name:        "regions",
+			description: "Displays the regions of the Pokemon world",
+			callback:    commandRegions,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Takes an area name as an argument. Travels to an area connected to the one you are in. Without an argument, shows where you are and where you can go",
+			callback:    commandTravel,
+		},
 		"explore": {
 			name:        "explore",
-			description: "Takes a location name as an argument. Displays all the Pokemon in a given area",
+			description: "Displays the Pokemon in the area you are in with their encounter method, levels and chance. Use --version <game> to filter by game version",
 			callback:    commandExplore,
 		},
+		"encounter": {
+			name:        "encounter",
+			description: "Looks for a wild pokemon in the area you are in. Use --method <method> to fish or surf instead of walking, --version <game> to pick a game version instead of the ones of the game command and --sprite <game> to pick its sprite",
+			callback:    commandEncounter,
+		},
+		"challenge": {
+			name:        "challenge",
+			description: "Takes a trainer name as an argument. Challenges a trainer or gym leader in the area you are in to a battle. Without an argument, lists the trainers here",
+			callback:    commandChallenge,
+		},
+		"badges": {
+			name:        "badges",
+			description: "Displays the gym badges you have earned",
+			callback:    commandBadges,
+		},
+		"fight": {
+			name:        "fight",
+			description: "Takes a move name as an argument. Attacks in a battle. Without an argument, lists your pokemon's moves",
+			callback:    commandFight,
+		},
+		"switch": {
+			name:        "switch",
+			description: "Takes a pokemon ID as an argument. Switches to another party pokemon in a battle",
+			callback:    commandSwitch,
+		},
+		"run": {
+			name:        "run",
+			description: "Tries to run away from a wild pokemon",
+			callback:    commandRun,
+		},
 		"catch": {
 			name:        "catch",
-			description: "Lets you attempt to catch a pokemon. Difficulty scales with base experience of the pokemon",
+			description: "Lets you attempt to catch the wild pokemon you encountered. Difficulty depends on its capture rate and remaining HP, so weaken it in battle first. Use --ball <ball> to throw a great-ball, ultra-ball or master-ball",
 			callback:    commandCatch,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Lets you inspect a pokemon you've caught before",
+			description: "Takes a species name, nickname or ID as an argument. Lets you inspect a pokemon you've caught before. Use --sprite <game> to draw it as in a game like red-blue, or --sprite off, and --radar to chart its base stats",
 			callback:    commandInspect,
 		},
+		"compare": {
+			name:        "compare",
+			description: "Takes two or more species names, nicknames or IDs. Compares pokemon side by side: size, types, abilities, stats, type matchups and speed",
+			callback:    commandCompare,
+		},
+		"index": {
+			name:        "index",
+			description: "Downloads the base stats of every species, so inspect can rank stats against them",
+			callback:    commandIndex,
+		},
+		"where": {
+			name:        "where",
+			description: "Takes a pokemon name as an argument. Lists the location areas where it can be found, best chance first. Use --version <game> to filter by game version",
+			callback:    commandWhere,
+		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "Displays the pokemon you've caught",
+			description: "Displays the pokemon you've caught with their IDs and how many species you've seen. Takes an optional species name to only list those",
 			callback:    commandPokedex,
 		},
+		"progress": {
+			name:        "progress",
+			description: "Displays how many pokemon you've seen and caught per generation and per regional Pokedex, and what's still missing in your region",
+			callback:    commandProgress,
+		},
+		"party": {
+			name:        "party",
+			description: "Manages the six pokemon you travel with: party, party add <id>, party remove <id>, party swap <a> <b>",
+			callback:    commandParty,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Takes a pokemon ID and a name. Gives one of your pokemon a nickname",
+			callback:    commandNickname,
+		},
+		"release": {
+			name:        "release",
+			description: "Takes a pokemon ID as an argument. Releases one of your pokemon into the wild",
+			callback:    commandRelease,
+		},
+		"box": {
+			name:        "box",
+			description: "Manages your PC boxes: box list [box], box move <id> <box>, box rename <box> <name>",
+			callback:    commandBox,
+		},
+		"heal": {
+			name:        "heal",
+			description: "Restores the HP, PP and status of your party at a Pokemon Center",
+			callback:    commandHeal,
+		},
+		"trade": {
+			name:        "trade",
+			description: "Takes a pokemon ID as an argument. Trades a pokemon to a friend and back, which makes some pokemon evolve",
+			callback:    commandTrade,
+		},
+		"bag": {
+			name:        "bag",
+			description: "Displays the items in your bag and your money",
+			callback:    commandBag,
+		},
+		"buy": {
+			name:        "buy",
+			description: "Takes an item name and an optional quantity. Buys items at their PokeAPI price",
+			callback:    commandBuy,
+		},
+		"use": {
+			name:        "use",
+			description: "Takes an item name and an optional pokemon ID. Uses an item from your bag, like a Poke Ball in an encounter, or a potion or evolution stone on one of your pokemon",
+			callback:    commandUse,
+		},
 	}
 }
 
 func commandHelp(cfg *config) error {
 	commands := getCommands()
-	commandOrder := []string{"help", "exit", "map", "mapb", "explore", "catch", "inspect", "pokedex"}
+	commandOrder := []string{"help", "exit", "save", "game", "map", "mapb", "regions", "travel", "explore", "where", "encounter", "challenge", "badges", "fight", "switch", "run", "catch", "inspect", "compare", "index", "pokedex", "progress", "party", "nickname", "release", "box", "heal", "trade", "bag", "buy", "use"}
 	fmt.Println()
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage: ")
@@ -89,11 +253,59 @@ func commandHelp(cfg *config) error {
 }
 
 func commandExit(cfg *config) error {
+	if err := commandSave(cfg); err != nil {
+		return err
+	}
 	os.Exit(0)
 	return nil
 }
 
+func commandGame(cfg *config) error {
+	if len(cfg.args) == 0 {
+		fmt.Printf("Your pokemon learn moves as in %s.\n", cfg.versionGroup)
+		fmt.Printf("This session's random seed is %d. Start with --seed %d to replay it.\n", cfg.seed, cfg.seed)
+		return nil
+	}
+	_, err := cfg.client.GetVersionGroupVersions("https://pokeapi.co/api/v2/version-group/"+cfg.args[0], cfg.cache)
+	if errors.Is(err, api.ErrNotFound) {
+		fmt.Printf("There is no game called %s. Try one like red-blue or gold-silver.\n", cfg.args[0])
+		return err
+	}
+	if err != nil {
+		fmt.Printf("Could not look up %s: %v\n", cfg.args[0], err)
+		return err
+	}
+	cfg.versionGroup = cfg.args[0]
+	fmt.Printf("Your pokemon now learn moves as in %s.\n", cfg.versionGroup)
+	return nil
+}
+
+func commandSave(cfg *config) error {
+	err := save.Write(cfg.savePath, save.State{
+		Location:     cfg.location,
+		Inventory:    cfg.inventory,
+		Pokedex:      cfg.pokedex,
+		VersionGroup: cfg.versionGroup,
+		Badges:       cfg.badges,
+		Defeated:     cfg.defeated,
+		Seed:         cfg.seed,
+	})
+	if err != nil {
+		fmt.Println("Could not save your game:", err)
+		return err
+	}
+	fmt.Println("Your game was saved.")
+	return nil
+}
+
 func commandMap(cfg *config) error {
+	_, flags := parseArgs(cfg.args)
+	if region, ok := flags["region"]; ok {
+		return selectRegion(cfg, region)
+	}
+	if cfg.region != "" {
+		return showRegionPage(cfg, cfg.regionOffset+locationsPerPage)
+	}
 
 	prev, next, locations, err := cfg.client.GetLocations(cfg.next, cfg.cache)
 	if err != nil {
@@ -108,6 +320,13 @@ func commandMap(cfg *config) error {
 }
 
 func commandMapb(cfg *config) error {
+	if cfg.region != "" {
+		if cfg.regionOffset == 0 {
+			fmt.Println("Can not display previous locations because they do not exist. Use map instead.")
+			return fmt.Errorf("no previous locations in %s", cfg.region)
+		}
+		return showRegionPage(cfg, cfg.regionOffset-locationsPerPage)
+	}
 
 	if cfg.prev == nil {
 		fmt.Println("Can not display previous locations because they do not exist. Use map instead.")
@@ -126,101 +345,514 @@ func commandMapb(cfg *config) error {
 }
 
 func commandExplore(cfg *config) error {
-	if len(cfg.args) != 1 {
-		fmt.Println("You must provide a location name")
-		return errors.New("you must provide a location name")
+	args, flags := parseArgs(cfg.args)
+	if len(args) > 0 && args[0] != cfg.location {
+		fmt.Printf("You can only explore the area you are in. Use travel to get to %s.\n", args[0])
+		return errors.New("not in that area")
 	}
-	fullURL := "https://pokeapi.co/api/v2/location-area/" + cfg.args[0]
-	pokemon, err := cfg.client.ExploreLocation(fullURL, cfg.cache)
+	encounters, err := exploreCurrentArea(cfg)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Exploring ", cfg.args[0])
+	if version, ok := flags["version"]; ok {
+		encounters = filterVersion(encounters, version)
+	}
+	fmt.Println("Exploring ", cfg.location)
+	if len(encounters) == 0 {
+		fmt.Println("No Pokemon found.")
+		return nil
+	}
 	fmt.Println("Found Pokemon:")
-	for i := 0; i < len(pokemon); i++ {
-		fmt.Println(" - ", pokemon[i])
+	printEncounters(encounters)
+	for _, e := range encounters {
+		if err := markSeen(cfg, e.Pokemon); err != nil {
+			fmt.Printf("Could not look up %s: %v\n", e.Pokemon, err)
+			return err
+		}
 	}
 	return nil
 }
 
-func commandCatch(cfg *config) error {
+// markSeen looks up a pokemon by name and records its species as seen.
+func markSeen(cfg *config, name string) error {
+	pokemon, err := cfg.client.GetPokemonInfo("https://pokeapi.co/api/v2/pokemon/"+name, cfg.cache)
+	if err != nil {
+		return err
+	}
+	cfg.pokedex.MarkSeen(pokemon)
+	return nil
+}
 
-	if len(cfg.args) != 1 {
-		fmt.Println("You must specify a pokemon!")
-		return errors.New("Not enough arguments")
+// exploreCurrentArea returns the encounters of the player's area. Areas that
+// PokeAPI has no encounter data for, like most towns, have no wild Pokemon.
+func exploreCurrentArea(cfg *config) ([]api.Encounter, error) {
+	fullURL := "https://pokeapi.co/api/v2/location-area/" + cfg.location
+	encounters, err := cfg.client.ExploreLocation(fullURL, cfg.cache)
+	if errors.Is(err, api.ErrNotFound) {
+		return []api.Encounter{}, nil
 	}
-	fullURL := "https://pokeapi.co/api/v2/pokemon/" + cfg.args[0]
-	pokemon, err := cfg.client.GetPokemonInfo(fullURL, cfg.cache)
-	if err != nil {
+	return encounters, err
+}
+
+func filterVersion(encounters []api.Encounter, version string) []api.Encounter {
+	filtered := []api.Encounter{}
+	for _, e := range encounters {
+		if e.Version == version {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+func printEncounters(encounters []api.Encounter) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, " POKEMON\tMETHOD\tLEVELS\tCHANCE\tVERSION")
+	for _, e := range encounters {
+		fmt.Fprintf(w, " %s\t%s\t%s\t%d%%\t%s\n", e.Pokemon, e.Method, levelRange(e.MinLevel, e.MaxLevel), e.Chance, e.Version)
+	}
+	w.Flush()
+}
+
+func levelRange(minLevel, maxLevel int) string {
+	if minLevel == maxLevel {
+		return fmt.Sprintf("%d", minLevel)
+	}
+	return fmt.Sprintf("%d-%d", minLevel, maxLevel)
+}
+
+// parseArgs splits command arguments into positional arguments and
+// "--name value" flags. A flag with no value is recorded as "true".
+func parseArgs(args []string) ([]string, map[string]string) {
+	positional := []string{}
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		name, isFlag := strings.CutPrefix(args[i], "--")
+		if !isFlag {
+			positional = append(positional, args[i])
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			flags[name] = args[i+1]
+			i++
+		} else {
+			flags[name] = "true"
+		}
+	}
+	return positional, flags
+}
+
+func commandCatch(cfg *config) error {
+	if cfg.opponent != nil {
+		fmt.Println("The trainer blocked the ball! Don't be a thief!")
+		return errors.New("trainer battle")
+	}
+	if cfg.wild == nil {
+		fmt.Println("There is no wild pokemon to catch! Use encounter to find one.")
+		return errors.New("no wild encounter")
+	}
+	args, flags := parseArgs(cfg.args)
+	wild := cfg.wild
+	pokemon := wild.pokemon
+	if len(args) > 0 && args[0] != pokemon.Name {
+		fmt.Printf("There is no wild %s here, only %s.\n", args[0], pokemon.Name)
+		return errors.New("pokemon not encountered")
+	}
+	ballName, ok := flags["ball"]
+	if !ok {
+		ballName = "poke-ball"
+	}
+	ball, ok := capture.Balls[ballName]
+	if !ok {
+		fmt.Printf("%s is not a kind of Poke Ball.\n", ballName)
+		return errors.New("unknown ball")
+	}
+	if err := cfg.inventory.Remove(ballName, 1); err != nil {
+		fmt.Printf("You don't have any %ss left! Use buy to get more.\n", ballName)
 		return err
 	}
-	res := rand.Intn(pokemon.BaseExperience)
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemon.Name)
-	if res > 40 {
-		fmt.Printf("%s escaped!\n", pokemon.Name)
+	fmt.Printf("Throwing a %s at %s (Lv. %d)...\n", ball.Name, pokemon.Name, wild.level)
+	res := capture.Throw(capture.Attempt{
+		CaptureRate: wild.species.CaptureRate,
+		MaxHP:       wild.combatant.MaxHP(),
+		HP:          wild.combatant.HP,
+		Ball:        ball,
+		Status:      wild.combatant.Status,
+	}, cfg.rng)
+	for i := 0; i < res.Shakes; i++ {
+		fmt.Println("  ...wobble...")
+	}
+	if !res.Caught {
+		fmt.Println(breakFreeMessages[res.Shakes])
+		if cfg.battle != nil {
+			playTurn(cfg, battle.Action{Kind: battle.Pass})
+		}
 		return nil
 	}
 
-	fmt.Printf("%s was caught!\n", pokemon.Name)
+	caught := cfg.pokedex.Catch(pokemon, wild.level, cfg.location, time.Now())
+	caught.IVs = wild.ivs
+	caught.Nature = wild.nature
+	caught.Shiny = wild.shiny
+	if wild.form.FormName != "" {
+		caught.Form = wild.form.Name
+	}
+	if growth, err := growthRate(cfg, pokemon); err == nil {
+		caught.Experience = growth.ExperienceAt(caught.Level)
+	}
+	caught.Damage = wild.combatant.MaxHP() - wild.combatant.HP
+	caught.Status = wild.combatant.Status
+	for _, slot := range wild.combatant.Moves {
+		caught.Moves = append(caught.Moves, slot.Move.Name)
+	}
+	fmt.Printf("Gotcha! %s was caught! Its ID is %d.\n", pokemon.Name, caught.ID)
+	if caught.Box != pokedex.PartyBox {
+		fmt.Printf("Your party is full, so %s was sent to %s.\n", pokemon.Name, cfg.pokedex.Boxes[caught.Box])
+	}
 
-	cfg.pokedex.Entries[pokemon.Name] = pokemon
+	endBattle(cfg)
 	return nil
 }
 
+var breakFreeMessages = []string{
+	"Oh no! The Pokemon broke free!",
+	"Aww! It appeared to be caught!",
+	"Aargh! Almost had it!",
+	"Shoot! It was so close, too!",
+}
+
 func commandInspect(cfg *config) error {
-	if len(cfg.args) != 1 {
+	args, flags := parseArgs(cfg.args)
+	if len(args) != 1 {
 		fmt.Println("You must specify a pokemon to inspect!")
 		return errors.New("Missing argument")
 	}
-	pokemon, exists := cfg.pokedex.Entries[cfg.args[0]]
-	if exists {
-		fmt.Printf("Name: %s\n", pokemon.Name)
-		fmt.Printf("Height: %d\n", pokemon.Height)
-		fmt.Printf("Weight: %d\n", pokemon.Weight)
-		fmt.Println("Stats: ")
-		for i := 0; i < len(pokemon.Stats); i++ {
-			fmt.Printf(" - %s: %d\n", pokemon.Stats[i].Stat.Name, pokemon.Stats[i].BaseStat)
-		}
-		fmt.Println("Types: ")
-		for i := 0; i < len(pokemon.Types); i++ {
-			fmt.Printf(" - %s\n", pokemon.Types[i].Type.Name)
-		}
-	} else {
+	found := cfg.pokedex.Find(args[0])
+	if len(found) == 0 {
 		fmt.Println("You have not caught that pokemon")
+		return nil
+	}
+
+	pokemon := cfg.pokedex.Species[found[0].Species]
+	if len(found) == 1 {
+		printSprite(cfg, pokemon, found[0].Form, found[0].Shiny, spriteVersion(cfg, flags))
+		printCaught(found[0])
+	} else {
+		printSprite(cfg, pokemon, "", false, spriteVersion(cfg, flags))
+	}
+	fmt.Printf("Name: %s\n", pokemon.Name)
+	fmt.Printf("Height: %d\n", pokemon.Height)
+	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	fmt.Println("Stats: ")
+	if len(found) == 1 {
+		printStats(cfg, pokemon, found[0])
+	} else {
+		printStats(cfg, pokemon, nil)
+	}
+	if _, ok := flags["radar"]; ok {
+		printRadar(pokemon)
+	}
+	fmt.Println("Types: ")
+	for i := 0; i < len(pokemon.Types); i++ {
+		fmt.Printf(" - %s\n", pokemon.Types[i].Type.Name)
+	}
+	if len(found) > 1 {
+		fmt.Printf("You have caught %d of them:\n", len(found))
+		printCaughtList(found)
+		fmt.Println("Use inspect <id> to inspect one of them.")
 	}
 	return nil
 }
 
+func printCaught(c *pokedex.Caught) {
+	fmt.Printf("ID: %d\n", c.ID)
+	if c.Nickname != "" {
+		fmt.Printf("Nickname: %s\n", c.Nickname)
+	}
+	if c.Shiny {
+		fmt.Println("Shiny: yes")
+	}
+	if c.Form != "" {
+		fmt.Printf("Form: %s\n", c.Form)
+	}
+	fmt.Printf("Level: %d (%d Exp.)\n", c.Level, c.Experience)
+	if c.Nature.Name != "" {
+		fmt.Printf("Nature: %s\n", c.Nature.Name)
+	}
+	if len(c.Moves) > 0 {
+		fmt.Printf("Moves: %s\n", strings.Join(c.Moves, ", "))
+	}
+	if c.Location == "" {
+		fmt.Printf("Caught: before %s\n", c.CaughtAt.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Caught: %s in %s\n", c.CaughtAt.Format("2006-01-02 15:04"), c.Location)
+	}
+}
+
+// statBarWidth is the width of the bar of the highest possible base stat,
+// 255.
+const statBarWidth = 25
+
+// printStats prints the pokemon's base stats with bars and, once the stat
+// index is built, how they rank against every species. For a caught pokemon
+// it also prints its IVs, EVs and actual stats.
+func printStats(cfg *config, pokemon pokedex.Pokemon, c *pokedex.Caught) {
+	base := pokedex.BaseStats(pokemon)
+	final := pokedex.Stats{}
+	if c != nil {
+		final = c.Stats(pokemon)
+	}
+	ranked := len(cfg.statIndex.Pokemon) > 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := " STAT\tBASE\t"
+	if c != nil {
+		header += "IV\tEV\tVALUE\t"
+	}
+	if ranked {
+		header += "BEATS\t"
+	}
+	fmt.Fprintln(w, header)
+
+	for _, name := range pokedex.StatNames {
+		row := fmt.Sprintf(" %s\t%d\t", name, base[name])
+		if c != nil {
+			marker := ""
+			switch c.Nature.Modifier(name) {
+			case 1.1:
+				marker = "+"
+			case 0.9:
+				marker = "-"
+			}
+			row += fmt.Sprintf("%d\t%d\t%d%s\t", c.IVs[name], c.EVs[name], final[name], marker)
+		}
+		if ranked {
+			row += fmt.Sprintf("%.0f%%\t", cfg.statIndex.Percentile(name, base[name]))
+		}
+		fmt.Fprintln(w, row+" "+statBar(cfg, base[name]))
+	}
+
+	total := statindex.TotalOf(base)
+	row := fmt.Sprintf(" total\t%d\t", total)
+	if c != nil {
+		row += fmt.Sprintf("\t\t%d\t", statindex.TotalOf(final))
+	}
+	if ranked {
+		row += fmt.Sprintf("%.0f%%\t", cfg.statIndex.Percentile(statindex.Total, total))
+	}
+	fmt.Fprintln(w, row)
+	w.Flush()
+	if !ranked {
+		fmt.Println("Run index to rank these stats against every species.")
+	}
+}
+
+// statBar draws a base stat out of 255, coloured from red for low stats to
+// cyan for high ones when the terminal has colours.
+func statBar(cfg *config, value int) string {
+	bar := chart.Bar(value, 255, statBarWidth)
+	if cfg.colorMode == sprite.ASCII {
+		return bar
+	}
+	colour := "36"
+	switch {
+	case value < 50:
+		colour = "31"
+	case value < 80:
+		colour = "33"
+	case value < 110:
+		colour = "32"
+	}
+	return "\x1b[" + colour + "m" + bar + "\x1b[0m"
+}
+
+// radarStats are the stats in the order the games draw them on a radar
+// chart, clockwise from the top.
+var radarStats = []struct {
+	name  string
+	label string
+}{
+	{name: "hp", label: "HP"},
+	{name: "attack", label: "Atk"},
+	{name: "defense", label: "Def"},
+	{name: "speed", label: "Spe"},
+	{name: "special-defense", label: "SpD"},
+	{name: "special-attack", label: "SpA"},
+}
+
+func printRadar(pokemon pokedex.Pokemon) {
+	base := pokedex.BaseStats(pokemon)
+	values := []float64{}
+	labels := []string{}
+	for _, stat := range radarStats {
+		values = append(values, float64(base[stat.name])/255)
+		labels = append(labels, stat.label)
+	}
+	fmt.Print(chart.Radar(values, labels, 16))
+}
+
+func printCaughtList(caught []*pokedex.Caught) {
+	for _, c := range caught {
+		name := c.Species
+		if c.Form != "" && c.Form != c.Species {
+			name = c.Form
+		}
+		if c.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", c.Nickname, name)
+		}
+		shiny := ""
+		if c.Shiny {
+			shiny = " *shiny*"
+		}
+		fmt.Printf(" - #%d %s Lv. %d%s\n", c.ID, name, c.Level, shiny)
+	}
+}
+
 func commandPokedex(cfg *config) error {
-	fmt.Println("Your Pokedex:")
-	for _, pokemon := range cfg.pokedex.Entries {
-		fmt.Printf(" - %s\n", pokemon.Name)
+	caught := cfg.pokedex.Instances()
+	if len(cfg.args) > 0 {
+		caught = cfg.pokedex.Find(cfg.args[0])
 	}
+	fmt.Printf("Your Pokedex: %d species seen, %d caught\n", len(cfg.pokedex.Seen), cfg.pokedex.CaughtSpecies())
+	printCaughtList(caught)
 	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "sim" {
+		if err := runSim(os.Args[2:]); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	_, flags := parseArgs(os.Args[1:])
+	seed := time.Now().UnixNano()
+	if value, ok := flags["seed"]; ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			fmt.Println("--seed must be a number.")
+			os.Exit(1)
+		}
+		seed = n
+	}
+	shinyOdds := pokedex.DefaultShinyOdds
+	if value, ok := flags["shiny-odds"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			fmt.Println("--shiny-odds must be a positive number, like 4096 for 1 in 4096.")
+			os.Exit(1)
+		}
+		shinyOdds = n
+	}
+	spriteVersion := pokedex.DefaultSprites
+	if value, ok := flags["sprite"]; ok {
+		if !validSpriteVersion(value) {
+			fmt.Printf("--sprite must be one of: %s\n", strings.Join(spriteVersions(), ", "))
+			os.Exit(1)
+		}
+		spriteVersion = value
+	}
+	colorMode := sprite.DetectMode()
+	if value, ok := flags["sprite-mode"]; ok {
+		mode, err := sprite.ParseMode(value)
+		if err != nil {
+			fmt.Println("--sprite-mode must be truecolor, 256 or ascii.")
+			os.Exit(1)
+		}
+		colorMode = mode
+	}
+
 	commands := getCommands()
 	c := pokecache.NewCache(5 * time.Second)
+	c.SetBudget(pokecache.Images, imageCacheBudget)
+	c.SetTTL(pokecache.Images, 0)
 	client := api.NewClient(5 * time.Second)
-	pokedex := pokedex.Pokedex{Entries: make(map[string]pokedex.Pokemon)}
+	w, err := world.Load()
+	if err != nil {
+		fmt.Println("Could not load route data:", err)
+		os.Exit(1)
+	}
+	trainers, err := trainer.Load()
+	if err != nil {
+		fmt.Println("Could not load trainer data:", err)
+		os.Exit(1)
+	}
+	savePath, err := save.DefaultPath()
+	if err != nil {
+		fmt.Println("Could not find a place to save your game:", err)
+		os.Exit(1)
+	}
+	statIndexPath, err := statindex.DefaultPath()
+	if err != nil {
+		fmt.Println("Could not find a place to keep the stat index:", err)
+		os.Exit(1)
+	}
+	statIndex, err := statindex.Load(statIndexPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Println("Could not load the stat index, run index to rebuild it:", err)
+	}
+	state, err := save.Load(savePath)
+	if errors.Is(err, os.ErrNotExist) {
+		state = save.State{
+			Location:     w.Start,
+			Inventory:    inventory.New(),
+			Pokedex:      pokedex.New(),
+			VersionGroup: defaultVersionGroup,
+		}
+	} else if err != nil {
+		fmt.Println("Could not load your saved game:", err)
+		os.Exit(1)
+	}
+
+	if state.Migrated > 0 {
+		fmt.Printf("Your save is from an older version, so its %d caught pokemon were moved over at level %d.\n", state.Migrated, save.LegacyLevel)
+	}
+	if _, exists := w.Area(state.Location); !exists {
+		state.Location = w.Start
+	}
+	if state.VersionGroup == "" {
+		state.VersionGroup = defaultVersionGroup
+	}
 
 	cfg := &config{
-		next:    "https://pokeapi.co/api/v2/location-area/",
-		prev:    nil,
-		cache:   c,
-		client:  client,
-		pokedex: pokedex,
+		next:          "https://pokeapi.co/api/v2/location-area/",
+		prev:          nil,
+		cache:         c,
+		client:        client,
+		pokedex:       state.Pokedex,
+		world:         w,
+		location:      state.Location,
+		inventory:     state.Inventory,
+		savePath:      savePath,
+		versionGroup:  state.VersionGroup,
+		trainers:      trainers,
+		badges:        state.Badges,
+		defeated:      state.Defeated,
+		scanner:       bufio.NewScanner(os.Stdin),
+		rng:           rand.New(rand.NewSource(seed)),
+		seed:          seed,
+		shinyOdds:     shinyOdds,
+		spriteVersion: spriteVersion,
+		colorMode:     colorMode,
+		statIndex:     statIndex,
+		statIndexPath: statIndexPath,
 	}
 
-	reader := bufio.NewScanner(os.Stdin)
+	reader := cfg.scanner
 	for {
 		fmt.Print("pokedex > ")
-		reader.Scan()
-		parts := reader.Text()
-		cmd := strings.Fields(parts)[0]
-		cfg.args = strings.Fields(parts)[1:]
+		if !reader.Scan() {
+			fmt.Println()
+			if err := commandExit(cfg); err != nil {
+				os.Exit(1)
+			}
+		}
+		parts := strings.Fields(reader.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		cmd := parts[0]
+		cfg.args = parts[1:]
 
 		if cmd, ok := commands[cmd]; ok {
 			cmd.callback(cfg)
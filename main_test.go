@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samersawan/pokedexcli/internal/api"
+	"github.com/samersawan/pokedexcli/internal/inventory"
+	"github.com/samersawan/pokedexcli/internal/pokecache"
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+	"github.com/samersawan/pokedexcli/internal/save"
+)
+
+func TestCommandSaveRoundTrip(t *testing.T) {
+	cfg := &config{
+		savePath:     filepath.Join(t.TempDir(), "save.json"),
+		location:     "pallet-town-area",
+		inventory:    inventory.New(),
+		pokedex:      pokedex.New(),
+		versionGroup: "red-blue",
+		badges:       []string{"boulder-badge"},
+		defeated:     []string{"brock", "youngster-ben"},
+		seed:         42,
+	}
+	if err := commandSave(cfg); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	state, err := save.Load(cfg.savePath)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if len(state.Badges) != 1 || state.Badges[0] != "boulder-badge" {
+		t.Errorf("expected badges [boulder-badge], got %v", state.Badges)
+	}
+	if len(state.Defeated) != 2 || state.Defeated[0] != "brock" || state.Defeated[1] != "youngster-ben" {
+		t.Errorf("expected defeated [brock youngster-ben], got %v", state.Defeated)
+	}
+	if state.Seed != 42 {
+		t.Errorf("expected seed 42, got %d", state.Seed)
+	}
+}
+
+func TestCommandGameValidatesVersionGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/version-group/gold-silver" {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name": "gold-silver", "versions": [{"name": "gold"}, {"name": "silver"}]}`))
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := &config{
+		cache:        pokecache.NewCache(5 * time.Second),
+		client:       api.NewClientWithTransport(5*time.Second, serverTransport{server: serverURL}),
+		versionGroup: "red-blue",
+	}
+
+	cfg.args = []string{"gold-silvr"}
+	if err := commandGame(cfg); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown game, got %v", err)
+	}
+	if cfg.versionGroup != "red-blue" {
+		t.Errorf("expected an unknown game to be ignored, got %s", cfg.versionGroup)
+	}
+	cfg.args = []string{"gold-silver"}
+	if err := commandGame(cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if cfg.versionGroup != "gold-silver" {
+		t.Errorf("expected gold-silver, got %s", cfg.versionGroup)
+	}
+}
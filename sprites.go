@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samersawan/pokedexcli/internal/pokedex"
+	"github.com/samersawan/pokedexcli/internal/sprite"
+)
+
+// spritesOff is the sprite version that turns sprites off.
+const spritesOff = "off"
+
+const spriteWidth = 48
+
+// spriteVersion returns the sprite version picked with --sprite, or the
+// session's default, which is picked with --sprite at startup.
+func spriteVersion(cfg *config, flags map[string]string) string {
+	if version, ok := flags["sprite"]; ok {
+		return version
+	}
+	return cfg.spriteVersion
+}
+
+// spriteVersions lists the versions --sprite accepts.
+func spriteVersions() []string {
+	return append(pokedex.SpriteVersions(), spritesOff)
+}
+
+func validSpriteVersion(version string) bool {
+	for _, v := range spriteVersions() {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// printSprite draws a pokemon's sprite. Pokemon caught in an alternate form
+// are drawn in that form. Sprites are a nice-to-have, so a sprite that can't
+// be shown doesn't fail the command.
+func printSprite(cfg *config, pokemon pokedex.Pokemon, form string, shiny bool, version string) {
+	if version == spritesOff {
+		return
+	}
+	url, err := pokedex.SpriteURL(pokemon, version, shiny)
+	if errors.Is(err, pokedex.ErrUnknownSpriteVersion) {
+		fmt.Printf("There are no %s sprites. Try one of: %s\n", version, strings.Join(spriteVersions(), ", "))
+		return
+	}
+	if form != "" && version == pokedex.DefaultSprites {
+		if f, err := cfg.client.GetPokemonForm("https://pokeapi.co/api/v2/pokemon-form/"+form, cfg.cache); err == nil {
+			if shiny && f.Sprites.FrontShiny != "" {
+				url = f.Sprites.FrontShiny
+			} else if f.Sprites.FrontDefault != "" {
+				url = f.Sprites.FrontDefault
+			}
+		}
+	}
+	if url == "" {
+		fmt.Printf("%s has no %s sprite.\n", pokemon.Name, version)
+		return
+	}
+
+	img, err := cfg.client.GetImage(url, cfg.cache)
+	if err != nil {
+		fmt.Println("Could not load the sprite:", err)
+		return
+	}
+	fmt.Print(sprite.Render(img, cfg.colorMode, spriteWidth))
+}